@@ -1,10 +1,17 @@
 package main
 
 import (
+	"context"
 	"coolifymanager/src"
 	"coolifymanager/src/config"
+	"errors"
 	"fmt"
 	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
@@ -14,20 +21,30 @@ import (
 var allowedUpdates = []string{"message", "callback_query"}
 
 func main() {
-	if err := config.Init(); err != nil {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := config.Init(ctx); err != nil {
 		log.Fatalf("❌ Failed to initialize config: %v", err)
 	}
+	if info, err := config.Coolify.ProbeVersion(); err != nil {
+		log.Printf("⚠️ Coolify version probe failed, using API %s for now: %v", config.Coolify.APIVersion, err)
+	} else {
+		log.Printf("🧭 Coolify %s on API %s", info.CoolifyVersion, info.APIVersion)
+	}
 
 	bot, err := initBot()
 	if err != nil {
 		log.Fatalf("❌ Failed to create bot: %v", err)
 	}
 
+	src.SetCommands(bot)
 	updater := ext.NewUpdater(src.Dispatcher, nil)
 
+	var server *http.Server
 	if config.WebhookUrl != "" && config.Port != "" {
 		log.Println("🌐 Starting bot in Webhook mode...")
-		if err := startWebhookBot(updater, bot, config.WebhookUrl, "super-secret-token"); err != nil {
+		if server, err = startWebhookBot(updater, bot, config.WebhookUrl, "super-secret-token"); err != nil {
 			log.Fatalf("❌ Webhook init failed: %v", err)
 		}
 	} else {
@@ -37,7 +54,26 @@ func main() {
 		}
 	}
 
+	src.StartWatcher(bot)
+	src.StartScheduler(bot)
 	log.Printf("🤖 Bot @%s is now running...\n", bot.User.Username)
+
+	// A signal cancels in-flight Coolify requests and stops the updater, which ends Idle.
+	go func() {
+		<-ctx.Done()
+		log.Println("🛑 Shutting down...")
+		if server != nil {
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			if err := server.Shutdown(shutdownCtx); err != nil {
+				log.Printf("❌ Failed to stop webhook server: %v", err)
+			}
+		}
+		if err := updater.Stop(); err != nil {
+			log.Printf("❌ Failed to stop updater: %v", err)
+		}
+		config.Coolify.Cache().Close()
+	}()
 	updater.Idle()
 }
 
@@ -62,20 +98,31 @@ func startLongPollingBot(updater *ext.Updater, bot *gotgbot.Bot) error {
 	})
 }
 
-func startWebhookBot(updater *ext.Updater, bot *gotgbot.Bot, domain, webhookSecret string) error {
-	opts := ext.WebhookOpts{
-		ListenAddr:  "0.0.0.0:" + config.Port,
-		SecretToken: webhookSecret,
+// startWebhookBot serves Telegram updates and Coolify notifications; the caller
+// shuts the returned server down on exit.
+func startWebhookBot(updater *ext.Updater, bot *gotgbot.Bot, domain, webhookSecret string) (*http.Server, error) {
+	mux := http.NewServeMux()
+	if config.CoolifyWebhookSecret != "" {
+		mux.Handle(config.CoolifyWebhookPath, src.CoolifyWebhookHandler(bot))
+		log.Printf("🔔 Accepting Coolify notifications on %s", config.CoolifyWebhookPath)
 	}
+	mux.Handle("/", updater.GetHandlerFunc("/"))
 
-	if err := updater.StartServer(opts); err != nil {
-		return fmt.Errorf("failed to start webhook server: %w", err)
+	ln, err := net.Listen("tcp", "0.0.0.0:"+config.Port)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start webhook server: %w", err)
 	}
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		if err := server.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("❌ Webhook server failed: %v", err)
+		}
+	}()
 
 	if err := updater.AddWebhook(bot, bot.Token, &ext.AddWebhookOpts{
 		SecretToken: webhookSecret,
 	}); err != nil {
-		return fmt.Errorf("failed to add webhook: %w", err)
+		return nil, fmt.Errorf("failed to add webhook: %w", err)
 	}
 
 	if err := updater.SetAllBotWebhooks(domain, &gotgbot.SetWebhookOpts{
@@ -84,8 +131,8 @@ func startWebhookBot(updater *ext.Updater, bot *gotgbot.Bot, domain, webhookSecr
 		DropPendingUpdates: true,
 		SecretToken:        webhookSecret,
 	}); err != nil {
-		return fmt.Errorf("failed to set webhook: %w", err)
+		return nil, fmt.Errorf("failed to set webhook: %w", err)
 	}
 
-	return nil
+	return server, nil
 }
@@ -0,0 +1,292 @@
+package src
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+
+	"coolifymanager/src/config"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+)
+
+// resourceKind says what the UUID after a callback prefix refers to, so
+// environment-scoped grants can be resolved for it.
+type resourceKind int
+
+const (
+	notScoped resourceKind = iota
+	appResource
+	serviceResource
+	databaseResource
+)
+
+type callbackPermission struct {
+	role     config.Role
+	resource resourceKind
+}
+
+// callbackPermissions maps a callback prefix to the role it requires.
+// Prefixes missing from the table are admin-only.
+var callbackPermissions = map[string]callbackPermission{
+	"list_projects":     {config.RoleViewer, notScoped},
+	"list_deployments":  {config.RoleViewer, notScoped},
+	"list_environments": {config.RoleViewer, notScoped},
+	"list_databases":    {config.RoleViewer, notScoped},
+	"db_menu":           {config.RoleViewer, databaseResource},
+	"db_start":          {config.RoleDeployer, databaseResource},
+	"db_restart":        {config.RoleDeployer, databaseResource},
+	"db_backups":        {config.RoleViewer, databaseResource},
+	"db_bkp_now":        {config.RoleDeployer, databaseResource},
+	"db_stop":           {config.RoleAdmin, databaseResource},
+	"db_del":            {config.RoleAdmin, databaseResource},
+	"db_delete":         {config.RoleAdmin, databaseResource},
+	"list_services":     {config.RoleViewer, notScoped},
+	"browse":            {config.RoleViewer, notScoped},
+	"browse_proj":       {config.RoleViewer, notScoped},
+	"browse_env":        {config.RoleViewer, notScoped},
+	"list_servers":      {config.RoleViewer, notScoped},
+	"server_menu":       {config.RoleViewer, notScoped},
+	"srv_validate":      {config.RoleAdmin, notScoped},
+	"version_probe":     {config.RoleAdmin, notScoped},
+	"cache_stats":       {config.RoleAdmin, notScoped},
+	"cache_flush":       {config.RoleAdmin, notScoped},
+	"service_menu":      {config.RoleViewer, serviceResource},
+	"svc_start":         {config.RoleDeployer, serviceResource},
+	"svc_restart":       {config.RoleDeployer, serviceResource},
+	"svc_envs":          {config.RoleDeployer, serviceResource},
+	"svc_stop":          {config.RoleAdmin, serviceResource},
+	"service_stop":      {config.RoleAdmin, serviceResource},
+	"project_menu":      {config.RoleViewer, appResource},
+	"app_deployments":   {config.RoleViewer, appResource},
+	"logs":              {config.RoleViewer, appResource},
+	"status":            {config.RoleViewer, appResource},
+	"app_envs":          {config.RoleDeployer, appResource},
+	"restart":           {config.RoleDeployer, appResource},
+	"deploy":            {config.RoleDeployer, appResource},
+	"rollback":          {config.RoleDeployer, appResource},
+	"unpin":             {config.RoleDeployer, appResource},
+	// dep_cancel carries a deployment UUID; the handler checks the application when known.
+	"dep_cancel": {config.RoleDeployer, notScoped},
+	"stop":       {config.RoleAdmin, appResource},
+	"delete":     {config.RoleAdmin, appResource},
+	"env_menu":   {config.RoleDeployer, appResource},
+	"env_add":    {config.RoleAdmin, appResource},
+	"env_edit":   {config.RoleAdmin, appResource},
+	"env_flag":   {config.RoleAdmin, appResource},
+	"env_del":    {config.RoleAdmin, appResource},
+	"env_delete": {config.RoleAdmin, appResource},
+	"env_export": {config.RoleAdmin, appResource},
+	"env_import": {config.RoleAdmin, appResource},
+	// na is the /newapp wizard; its values are project, server and key UUIDs.
+	"na":           {config.RoleAdmin, notScoped},
+	"app_settings": {config.RoleDeployer, appResource},
+	"set":          {config.RoleAdmin, appResource},
+	// set_save carries a nonce; the change's application is checked in the handler.
+	"set_save": {config.RoleAdmin, notScoped},
+	// env_apply carries a nonce; the plan's application is checked in the handler.
+	"env_apply": {config.RoleAdmin, notScoped},
+	// confirm is re-checked against the action being confirmed.
+	"confirm": {config.RoleViewer, notScoped},
+}
+
+// checkedInHandler lists non-app callbacks whose handler verifies the
+// application itself, so a grant of any scope lets them through.
+var checkedInHandler = map[string]bool{
+	"dep_cancel": true,
+	"set_save":   true,
+	"env_apply":  true,
+	"confirm":    true,
+}
+
+func permissionFor(action string) callbackPermission {
+	if p, ok := callbackPermissions[action]; ok {
+		return p
+	}
+	return callbackPermission{role: config.RoleAdmin}
+}
+
+// resourceRole resolves the role a user holds for a resource, looking up the
+// resource's environment only when an environment-scoped grant could apply.
+func resourceRole(reqCtx context.Context, userID int64, kind resourceKind, uuid string) config.Role {
+	if !config.HasEnvScopedGrant(userID) {
+		return config.RoleFor(userID, uuid)
+	}
+
+	switch kind {
+	case appResource:
+		if app, err := config.Coolify.GetApplicationByUUIDWithContext(reqCtx, uuid); err == nil {
+			return config.RoleFor(userID, uuid, app.Environment, strconv.FormatInt(app.EnvironmentID, 10))
+		}
+	case serviceResource:
+		if svc, err := config.Coolify.GetServiceByUUIDWithContext(reqCtx, uuid); err == nil {
+			return config.RoleFor(userID, uuid, environmentKeys(reqCtx, svc.EnvironmentID)...)
+		}
+	case databaseResource:
+		if db, err := config.Coolify.GetDatabaseByUUIDWithContext(reqCtx, uuid); err == nil {
+			return config.RoleFor(userID, uuid, environmentKeys(reqCtx, db.EnvironmentID)...)
+		}
+	}
+	return config.RoleFor(userID, uuid)
+}
+
+// environmentKeys returns the ID and, when it can be resolved, the name of an
+// environment; services and databases only carry the ID.
+func environmentKeys(reqCtx context.Context, envID int64) []string {
+	keys := []string{strconv.FormatInt(envID, 10)}
+	if page, err := config.Coolify.ListEnvironmentsWithContext(reqCtx, 0, 0); err == nil {
+		for _, env := range page.Results() {
+			if env.ID == envID {
+				keys = append(keys, env.Name)
+				break
+			}
+		}
+	}
+	return keys
+}
+
+// isAllowed reports whether userID may perform action, on uuid when the action is resource scoped.
+func isAllowed(reqCtx context.Context, userID int64, action, uuid string) bool {
+	perm := permissionFor(action)
+	if perm.resource != notScoped && uuid != "" {
+		return resourceRole(reqCtx, userID, perm.resource, uuid) >= perm.role
+	}
+	// Viewer navigation accepts a grant of any scope; other actions that aren't
+	// tied to one application need a global role.
+	if perm.resource == notScoped && (perm.role <= config.RoleViewer || checkedInHandler[action]) {
+		return config.HighestRole(userID) >= perm.role
+	}
+	return config.GlobalRole(userID) >= perm.role
+}
+
+// ensureAllowed checks the callback's action against the user's roles and
+// answers the query with an alert when access is denied.
+func ensureAllowed(b *gotgbot.Bot, ctx *ext.Context) bool {
+	action, rest, _ := strings.Cut(ctx.CallbackQuery.Data, ":")
+	uuid, _, _ := strings.Cut(rest, ":")
+	if permissionFor(action).resource == notScoped {
+		uuid = ""
+	}
+
+	if isAllowed(updateContext(ctx), ctx.EffectiveUser.Id, action, uuid) {
+		return true
+	}
+	_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{
+		Text:      "🚫 You are not authorized.",
+		ShowAlert: true,
+	})
+	return false
+}
+
+func ensureAdminCommand(b *gotgbot.Bot, ctx *ext.Context) bool {
+	if config.GlobalRole(ctx.EffectiveUser.Id) >= config.RoleAdmin {
+		return true
+	}
+	_, _ = ctx.EffectiveMessage.Reply(b, "🚫 You are not authorized.", nil)
+	return false
+}
+
+func grantCommandHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	if !ensureAdminCommand(b, ctx) {
+		return ext.EndGroups
+	}
+	msg := ctx.EffectiveMessage
+
+	args := strings.Fields(msg.Text)[1:]
+	if len(args) < 2 {
+		_, err := msg.Reply(b, "Usage: <code>/grant &lt;user_id&gt; &lt;viewer|deployer|admin&gt; [app:&lt;uuid&gt;|env:&lt;name&gt;]</code>", &gotgbot.SendMessageOpts{ParseMode: "HTML"})
+		return err
+	}
+
+	userID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		_, err = msg.Reply(b, "❌ User ID must be a number.", nil)
+		return err
+	}
+	role, ok := config.ParseRole(args[1])
+	if !ok {
+		_, err = msg.Reply(b, "❌ Role must be viewer, deployer or admin.", nil)
+		return err
+	}
+	scope := ""
+	if len(args) > 2 {
+		scope = args[2]
+	}
+
+	err = config.GrantRole(userID, role, scope)
+	recordAudit(b, ctx.EffectiveUser, "grant", strconv.FormatInt(userID, 10), "", fmt.Sprintf("%s %s", role, scope), err)
+	if err != nil {
+		_, err = msg.Reply(b, "❌ Grant failed: "+errorText(err), nil)
+		return err
+	}
+
+	scopeText, _ := config.NormalizeScope(scope)
+	if scopeText == "" {
+		scopeText = "global"
+	}
+	_, err = msg.Reply(b, fmt.Sprintf("✅ <code>%d</code> is now <b>%s</b> (%s).", userID, role, html.EscapeString(scopeText)), &gotgbot.SendMessageOpts{ParseMode: "HTML"})
+	return err
+}
+
+func revokeCommandHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	if !ensureAdminCommand(b, ctx) {
+		return ext.EndGroups
+	}
+	msg := ctx.EffectiveMessage
+
+	args := strings.Fields(msg.Text)[1:]
+	if len(args) < 1 {
+		_, err := msg.Reply(b, "Usage: <code>/revoke &lt;user_id&gt; [app:&lt;uuid&gt;|env:&lt;name&gt;|all]</code>", &gotgbot.SendMessageOpts{ParseMode: "HTML"})
+		return err
+	}
+
+	userID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		_, err = msg.Reply(b, "❌ User ID must be a number.", nil)
+		return err
+	}
+	scope := ""
+	if len(args) > 1 {
+		scope = args[1]
+	}
+
+	removed, err := config.RevokeRole(userID, scope)
+	recordAudit(b, ctx.EffectiveUser, "revoke", strconv.FormatInt(userID, 10), "", fmt.Sprintf("%d grant(s) %s", removed, scope), err)
+	if err != nil {
+		_, err = msg.Reply(b, "❌ Revoke failed: "+errorText(err), nil)
+		return err
+	}
+	if removed == 0 {
+		_, err = msg.Reply(b, "Nothing to revoke.", nil)
+		return err
+	}
+	_, err = msg.Reply(b, fmt.Sprintf("✅ Removed %d grant(s) from %d.", removed, userID), nil)
+	return err
+}
+
+func rolesCommandHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	if !ensureAdminCommand(b, ctx) {
+		return ext.EndGroups
+	}
+
+	grants := config.Grants()
+	if len(grants) == 0 {
+		_, err := ctx.EffectiveMessage.Reply(b, "No runtime grants. Users in DEV_IDS are admins.", nil)
+		return err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<b>👥 Roles</b>\n")
+	for _, g := range grants {
+		scope := g.Scope
+		if scope == "" {
+			scope = "global"
+		}
+		sb.WriteString(fmt.Sprintf("<code>%d</code> — %s (%s)\n", g.UserID, g.Role, html.EscapeString(scope)))
+	}
+	_, err := ctx.EffectiveMessage.Reply(b, sb.String(), &gotgbot.SendMessageOpts{ParseMode: "HTML"})
+	return err
+}
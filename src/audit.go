@@ -0,0 +1,133 @@
+package src
+
+import (
+	"fmt"
+	"html"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"coolifymanager/src/config"
+	coolifyPkg "coolifymanager/src/coolity"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+)
+
+const auditShown = 20
+
+var htmlTag = regexp.MustCompile(`<[^>]*>`)
+
+// plainText turns a bot HTML message back into text for the audit log.
+func plainText(s string) string {
+	return strings.TrimSpace(html.UnescapeString(htmlTag.ReplaceAllString(s, "")))
+}
+
+func userLabel(u *gotgbot.User) string {
+	if u.Username != "" {
+		return "@" + u.Username
+	}
+	return strings.TrimSpace(u.FirstName + " " + u.LastName)
+}
+
+// recordAudit stores who performed a mutating action and how it ended, and
+// mirrors the entry to LOG_ID when AUDIT_MIRROR is enabled. Failures to store
+// are logged; they never block the action itself.
+func recordAudit(b *gotgbot.Bot, user *gotgbot.User, action, target, targetName, message string, err error) {
+	entry := config.AuditEntry{
+		Time:       time.Now(),
+		UserID:     user.Id,
+		UserName:   userLabel(user),
+		Action:     action,
+		Target:     target,
+		TargetName: targetName,
+		OK:         err == nil,
+		Message:    truncateText(plainText(message), 500),
+	}
+	if err != nil {
+		entry.Message = truncateText(err.Error(), 500)
+	}
+
+	if storeErr := config.RecordAudit(entry); storeErr != nil {
+		log.Printf("audit: failed to record %s on %s by %d: %v", action, target, user.Id, storeErr)
+	}
+	if config.AuditMirror() {
+		if _, sendErr := b.SendMessage(config.LogChat(), "📝 "+formatAuditEntry(entry), &gotgbot.SendMessageOpts{ParseMode: "HTML"}); sendErr != nil {
+			log.Printf("audit: failed to mirror entry: %v", sendErr)
+		}
+	}
+}
+
+// deploymentMessage describes a queued deployment for the audit log; res is nil on failure.
+func deploymentMessage(res *coolifyPkg.StartDeploymentResponse) string {
+	if res == nil {
+		return ""
+	}
+	return strings.TrimSpace(res.Message + " " + res.DeploymentUUID)
+}
+
+func formatAuditEntry(e config.AuditEntry) string {
+	icon := "✅"
+	if !e.OK {
+		icon = "❌"
+	}
+	target := e.TargetName
+	if target == "" {
+		target = e.Target
+	}
+	text := fmt.Sprintf(
+		"%s <code>%s</code> <b>%s</b> %s by %s (<code>%d</code>)",
+		icon, e.Time.Format("2006-01-02 15:04:05"), html.EscapeString(e.Action),
+		html.EscapeString(target), html.EscapeString(e.UserName), e.UserID,
+	)
+	if e.Message != "" {
+		text += "\n    " + html.EscapeString(truncateText(e.Message, 120))
+	}
+	return text
+}
+
+// auditCommandHandler lists recent audit entries: /audit [app] [user].
+// A numeric or @-prefixed argument filters by user; anything else by application.
+func auditCommandHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	if !ensureAdminCommand(b, ctx) {
+		return ext.EndGroups
+	}
+	msg := ctx.EffectiveMessage
+
+	var appFilter, userFilter string
+	for _, arg := range commandArgs(msg) {
+		if _, err := strconv.ParseInt(arg, 10, 64); err == nil || strings.HasPrefix(arg, "@") {
+			userFilter = arg
+		} else {
+			appFilter = strings.ToLower(arg)
+		}
+	}
+
+	entries, err := config.QueryAudit(func(e config.AuditEntry) bool {
+		if userFilter != "" && strconv.FormatInt(e.UserID, 10) != userFilter && !strings.EqualFold(e.UserName, userFilter) {
+			return false
+		}
+		if appFilter != "" && !strings.HasPrefix(strings.ToLower(e.Target), appFilter) && !strings.Contains(strings.ToLower(e.TargetName), appFilter) {
+			return false
+		}
+		return true
+	}, auditShown)
+	if err != nil {
+		_, err = msg.Reply(b, "❌ Failed to read the audit log: "+errorText(err), nil)
+		return err
+	}
+	if len(entries) == 0 {
+		_, err = msg.Reply(b, "No matching audit entries.", nil)
+		return err
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("<b>📝 Last %d audit entries</b>\n", len(entries)))
+	for _, e := range entries {
+		sb.WriteString("\n" + formatAuditEntry(e))
+	}
+	_, err = replyHTML(b, msg, sb.String(), nil)
+	return err
+}
@@ -0,0 +1,171 @@
+package src
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"coolifymanager/src/config"
+	coolifyPkg "coolifymanager/src/coolity"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+)
+
+const backupExecutionsShown = 5
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+func backupStatusIcon(status string) string {
+	switch strings.ToLower(status) {
+	case "success":
+		return "✅"
+	case "failed":
+		return "❌"
+	case "running":
+		return "⏳"
+	default:
+		return "▫️"
+	}
+}
+
+func backupTarget(s coolifyPkg.ScheduledBackup) string {
+	if !s.SaveS3 {
+		return "local only"
+	}
+	if s.S3StorageID != nil {
+		return fmt.Sprintf("local + S3 storage #%d", *s.S3StorageID)
+	}
+	return "local + S3"
+}
+
+// describeExecution renders one backup run on a single line.
+func describeExecution(e coolifyPkg.BackupExecution) string {
+	parts := []string{backupStatusIcon(e.Status) + " " + html.EscapeString(orUnknown(e.Status))}
+	if e.Size > 0 {
+		parts = append(parts, formatBytes(e.Size))
+	}
+	when := e.FinishedAt
+	if when == "" {
+		when = e.CreatedAt
+	}
+	if when != "" {
+		parts = append(parts, html.EscapeString(when))
+	}
+	if e.S3Uploaded != nil && *e.S3Uploaded {
+		parts = append(parts, "S3 ✔")
+	}
+	return strings.Join(parts, " · ")
+}
+
+// latestBackup returns the most recent execution across all schedules of a database.
+func latestBackup(schedules []coolifyPkg.ScheduledBackup) *coolifyPkg.BackupExecution {
+	var latest *coolifyPkg.BackupExecution
+	for _, s := range schedules {
+		if e := s.LatestExecution(); e != nil && (latest == nil || e.ID > latest.ID) {
+			latest = e
+		}
+	}
+	return latest
+}
+
+func dbBackupsHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	cb := ctx.CallbackQuery
+	if !ensureAllowed(b, ctx) {
+		return nil
+	}
+	_, _ = cb.Answer(b, nil)
+
+	uuid := strings.TrimPrefix(cb.Data, "db_backups:")
+	schedules, err := config.Coolify.ListDatabaseBackupsWithContext(updateContext(ctx), uuid)
+	if err != nil {
+		_, _, err = cb.Message.EditText(b, "❌ Failed to fetch backups: "+errorText(err), nil)
+		return err
+	}
+
+	back := []gotgbot.InlineKeyboardButton{{Text: "🔙 Back", CallbackData: "db_menu:" + uuid}}
+	if len(schedules) == 0 {
+		_, _, err = cb.Message.EditText(b, "No backup schedules configured for this database.", &gotgbot.EditMessageTextOpts{
+			ReplyMarkup: gotgbot.InlineKeyboardMarkup{InlineKeyboard: [][]gotgbot.InlineKeyboardButton{back}},
+		})
+		return err
+	}
+
+	var sb strings.Builder
+	var btns [][]gotgbot.InlineKeyboardButton
+	sb.WriteString("<b>💾 Backup schedules</b>\n")
+	for _, s := range schedules {
+		state := "enabled"
+		if !s.Enabled {
+			state = "disabled"
+		}
+		sb.WriteString(fmt.Sprintf("\n⏰ <code>%s</code> (%s)\n📦 Target: %s\n", html.EscapeString(s.Frequency), state, backupTarget(s)))
+
+		executions, err := config.Coolify.ListBackupExecutionsWithContext(updateContext(ctx), uuid, s.UUID)
+		if err != nil {
+			executions = s.Executions
+		}
+		if len(executions) == 0 {
+			sb.WriteString("No runs yet.\n")
+		}
+		for i, e := range executions {
+			if i == backupExecutionsShown {
+				break
+			}
+			sb.WriteString(describeExecution(e) + "\n")
+			if strings.EqualFold(e.Status, "failed") && e.Message != "" && i == 0 {
+				sb.WriteString("<pre>" + html.EscapeString(truncateText(e.Message, 300)) + "</pre>\n")
+			}
+		}
+
+		btns = append(btns, []gotgbot.InlineKeyboardButton{
+			{Text: "▶️ Backup now (" + s.Frequency + ")", CallbackData: fmt.Sprintf("db_bkp_now:%s:%s", uuid, s.UUID)},
+		})
+	}
+	btns = append(btns, back)
+
+	_, _, err = cb.Message.EditText(b, sb.String(), &gotgbot.EditMessageTextOpts{
+		ParseMode:   "HTML",
+		ReplyMarkup: gotgbot.InlineKeyboardMarkup{InlineKeyboard: btns},
+	})
+	return err
+}
+
+func dbBackupNowHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	cb := ctx.CallbackQuery
+	if !ensureAllowed(b, ctx) {
+		return nil
+	}
+	_, _ = cb.Answer(b, nil)
+
+	parts := strings.Split(strings.TrimPrefix(cb.Data, "db_bkp_now:"), ":")
+	if len(parts) < 2 {
+		return nil
+	}
+	uuid, backupUUID := parts[0], parts[1]
+
+	text := "💾 Backup started. Check the schedule for its result in a moment."
+	err := config.Coolify.TriggerDatabaseBackupWithContext(updateContext(ctx), uuid, backupUUID)
+	recordAudit(b, ctx.EffectiveUser, "db_backup", uuid, "", "backup "+backupUUID, err)
+	if err != nil {
+		text = "❌ Backup failed to start: " + errorText(err)
+	}
+	_, _, err = cb.Message.EditText(b, text, &gotgbot.EditMessageTextOpts{
+		ReplyMarkup: gotgbot.InlineKeyboardMarkup{InlineKeyboard: [][]gotgbot.InlineKeyboardButton{
+			{{Text: "🔄 Refresh backups", CallbackData: "db_backups:" + uuid}},
+			{{Text: "🔙 Back", CallbackData: "db_menu:" + uuid}},
+		}},
+	})
+	return err
+}
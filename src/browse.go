@@ -0,0 +1,204 @@
+package src
+
+import (
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+
+	"coolifymanager/src/config"
+	coolifyPkg "coolifymanager/src/coolity"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+)
+
+const browsePerPage = 10
+
+// pageBounds slices a client-side list for the requested page and returns the
+// clamped page and the page count.
+func pageBounds(total, page, perPage int) (start, end, current, pages int) {
+	pages = maxInt(1, (total+perPage-1)/perPage)
+	current = minInt(maxInt(1, page), pages)
+	start = (current - 1) * perPage
+	end = minInt(start+perPage, total)
+	return start, end, current, pages
+}
+
+// environmentKey picks how an environment is addressed in callback data: its
+// UUID, or "#<id>" on Coolify versions without one. Names are never used since
+// they can be long or contain the ':' separator.
+func environmentKey(env coolifyPkg.Environment) string {
+	if env.UUID != "" {
+		return env.UUID
+	}
+	return "#" + strconv.FormatInt(env.ID, 10)
+}
+
+// findEnvironment returns the project's environment addressed by key.
+func findEnvironment(project *coolifyPkg.ProjectDetail, key string) (coolifyPkg.Environment, bool) {
+	for _, env := range project.Environments {
+		if environmentKey(env) == key {
+			return env, true
+		}
+	}
+	return coolifyPkg.Environment{}, false
+}
+
+func browseHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	if !ensureAllowed(b, ctx) {
+		return nil
+	}
+	cb := ctx.CallbackQuery
+	_, _ = cb.Answer(b, nil)
+
+	projects, err := config.Coolify.ListProjectsWithContext(updateContext(ctx))
+	if err != nil {
+		_, _, err = cb.Message.EditText(b, "❌ Failed to fetch projects: "+errorText(err), nil)
+		return err
+	}
+	if len(projects) == 0 {
+		_, _, err = cb.Message.EditText(b, "😶 No projects found.", nil)
+		return err
+	}
+
+	start, end, currentPage, totalPages := pageBounds(len(projects), parsePageFromCallback(cb.Data, "browse"), browsePerPage)
+	var buttons [][]gotgbot.InlineKeyboardButton
+	for _, p := range projects[start:end] {
+		buttons = append(buttons, []gotgbot.InlineKeyboardButton{
+			{Text: "🗂 " + p.Name, CallbackData: "browse_proj:" + p.UUID},
+		})
+	}
+	buttons = append(buttons,
+		buildPaginationRow("browse", currentPage, totalPages),
+		[]gotgbot.InlineKeyboardButton{{Text: "🔙 Back", CallbackData: "list_projects:1"}},
+	)
+
+	message := fmt.Sprintf("<b>🗂 Coolify projects</b>\nPage %d of %d", currentPage, totalPages)
+	_, _, err = cb.Message.EditText(b, message, &gotgbot.EditMessageTextOpts{
+		ParseMode:   "HTML",
+		ReplyMarkup: gotgbot.InlineKeyboardMarkup{InlineKeyboard: buttons},
+	})
+	return err
+}
+
+func browseProjectHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	cb := ctx.CallbackQuery
+	if !ensureAllowed(b, ctx) {
+		return nil
+	}
+	_, _ = cb.Answer(b, nil)
+
+	uuid := strings.TrimPrefix(cb.Data, "browse_proj:")
+	project, err := config.Coolify.GetProjectWithContext(updateContext(ctx), uuid)
+	if err != nil {
+		_, _, err = cb.Message.EditText(b, "❌ Failed to load project: "+errorText(err), nil)
+		return err
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("<b>🗂 %s</b>\n", html.EscapeString(project.Name)))
+	if project.Description != "" {
+		sb.WriteString(html.EscapeString(project.Description) + "\n")
+	}
+	if len(project.Environments) == 0 {
+		sb.WriteString("\nThis project has no environments.")
+	} else {
+		sb.WriteString("\nSelect an environment:")
+	}
+
+	var buttons [][]gotgbot.InlineKeyboardButton
+	for _, env := range project.Environments {
+		buttons = append(buttons, []gotgbot.InlineKeyboardButton{
+			{Text: "🌍 " + env.Name, CallbackData: fmt.Sprintf("browse_env:%s:%s", project.UUID, environmentKey(env))},
+		})
+	}
+	buttons = append(buttons, []gotgbot.InlineKeyboardButton{{Text: "🔙 Back", CallbackData: "browse:1"}})
+
+	_, _, err = cb.Message.EditText(b, sb.String(), &gotgbot.EditMessageTextOpts{
+		ParseMode:   "HTML",
+		ReplyMarkup: gotgbot.InlineKeyboardMarkup{InlineKeyboard: buttons},
+	})
+	return err
+}
+
+func browseEnvironmentHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	cb := ctx.CallbackQuery
+	if !ensureAllowed(b, ctx) {
+		return nil
+	}
+	_, _ = cb.Answer(b, nil)
+
+	parts := strings.Split(strings.TrimPrefix(cb.Data, "browse_env:"), ":")
+	if len(parts) < 2 {
+		return nil
+	}
+	projectUUID, envKey := parts[0], parts[1]
+	page := 1
+	if len(parts) > 2 {
+		if p, err := strconv.Atoi(parts[2]); err == nil {
+			page = p
+		}
+	}
+
+	project, err := config.Coolify.GetProjectWithContext(updateContext(ctx), projectUUID)
+	if err != nil {
+		_, _, err = cb.Message.EditText(b, "❌ Failed to load project: "+errorText(err), nil)
+		return err
+	}
+	found, ok := findEnvironment(project, envKey)
+	if !ok {
+		_, _, err = cb.Message.EditText(b, "❌ Environment not found. It may have been deleted.", nil)
+		return err
+	}
+	// Coolify looks environments up by UUID or name.
+	lookup := found.UUID
+	if lookup == "" {
+		lookup = found.Name
+	}
+	env, err := config.Coolify.GetProjectEnvironmentWithContext(updateContext(ctx), projectUUID, lookup)
+	if err != nil {
+		_, _, err = cb.Message.EditText(b, "❌ Failed to load environment: "+errorText(err), nil)
+		return err
+	}
+	projectName := project.Name
+
+	databases := env.Databases()
+	var items []gotgbot.InlineKeyboardButton
+	for _, app := range env.Applications {
+		items = append(items, gotgbot.InlineKeyboardButton{Text: fmt.Sprintf("📦 %s (%s)", app.Name, app.Status), CallbackData: "project_menu:" + app.UUID})
+	}
+	for _, db := range databases {
+		items = append(items, gotgbot.InlineKeyboardButton{Text: fmt.Sprintf("🗄 %s (%s)", db.Name, db.Status), CallbackData: "db_menu:" + db.UUID})
+	}
+	for _, svc := range env.Services {
+		items = append(items, gotgbot.InlineKeyboardButton{Text: fmt.Sprintf("🧩 %s (%s)", svc.Name, svc.Status), CallbackData: "service_menu:" + svc.UUID})
+	}
+
+	start, end, currentPage, totalPages := pageBounds(len(items), page, browsePerPage)
+	var buttons [][]gotgbot.InlineKeyboardButton
+	for _, item := range items[start:end] {
+		buttons = append(buttons, []gotgbot.InlineKeyboardButton{item})
+	}
+	if totalPages > 1 {
+		buttons = append(buttons, buildPaginationRow(fmt.Sprintf("browse_env:%s:%s", projectUUID, envKey), currentPage, totalPages))
+	}
+	buttons = append(buttons, []gotgbot.InlineKeyboardButton{{Text: "🔙 Back", CallbackData: "browse_proj:" + projectUUID}})
+
+	message := fmt.Sprintf(
+		"<b>🗂 %s / 🌍 %s</b>\n📦 %d apps · 🗄 %d databases · 🧩 %d services",
+		html.EscapeString(projectName), html.EscapeString(env.Name),
+		len(env.Applications), len(databases), len(env.Services),
+	)
+	if len(items) == 0 {
+		message += "\n\nThis environment is empty."
+	} else if totalPages > 1 {
+		message += fmt.Sprintf("\nPage %d of %d", currentPage, totalPages)
+	}
+
+	_, _, err = cb.Message.EditText(b, message, &gotgbot.EditMessageTextOpts{
+		ParseMode:   "HTML",
+		ReplyMarkup: gotgbot.InlineKeyboardMarkup{InlineKeyboard: buttons},
+	})
+	return err
+}
@@ -0,0 +1,91 @@
+package src
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"coolifymanager/src/config"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+)
+
+func cacheText() string {
+	stats := config.Coolify.Cache().Stats()
+	hitRate := 0.0
+	if lookups := stats.Hits + stats.Misses; lookups > 0 {
+		hitRate = float64(stats.Hits) * 100 / float64(lookups)
+	}
+
+	text := fmt.Sprintf(
+		"<b>🗃 API cache</b>\n\n"+
+			"📦 <b>Entries:</b> <code>%d / %d</code>\n"+
+			"🎯 <b>Hits:</b> <code>%d</code> · <b>Misses:</b> <code>%d</code> (%.1f%% hit rate)\n"+
+			"♻️ <b>Evicted:</b> <code>%d</code> · <b>Expired:</b> <code>%d</code>",
+		stats.Entries, stats.MaxEntries, stats.Hits, stats.Misses, hitRate, stats.Evictions, stats.Expired,
+	)
+	for _, g := range config.Coolify.Cache().Groups() {
+		text += fmt.Sprintf("\n• <code>%s</code> %d", html.EscapeString(g.Prefix), g.Entries)
+	}
+	return text
+}
+
+// cacheMarkup offers a flush button per key prefix, plus flush all and refresh.
+func cacheMarkup() gotgbot.InlineKeyboardMarkup {
+	var rows [][]gotgbot.InlineKeyboardButton
+	var row []gotgbot.InlineKeyboardButton
+	for _, g := range config.Coolify.Cache().Groups() {
+		row = append(row, gotgbot.InlineKeyboardButton{
+			Text:         fmt.Sprintf("🧹 %s (%d)", g.Prefix, g.Entries),
+			CallbackData: "cache_flush:" + g.Prefix,
+		})
+		if len(row) == 2 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+	rows = append(rows, []gotgbot.InlineKeyboardButton{
+		{Text: "🗑 Flush all", CallbackData: "cache_flush:"},
+		{Text: "🔄 Refresh", CallbackData: "cache_stats"},
+	})
+	return gotgbot.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+func cacheCommandHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	if !ensureAdminCommand(b, ctx) {
+		return ext.EndGroups
+	}
+
+	markup := cacheMarkup()
+	_, err := replyHTML(b, ctx.EffectiveMessage, cacheText(), &markup)
+	return err
+}
+
+// cacheStatsHandler handles "cache_stats" and "cache_flush:<prefix>"; an empty
+// prefix flushes everything.
+func cacheStatsHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	cb := ctx.CallbackQuery
+	if !ensureAllowed(b, ctx) {
+		return nil
+	}
+
+	if prefix, ok := strings.CutPrefix(cb.Data, "cache_flush:"); ok {
+		removed := config.Coolify.Cache().DeletePrefix(prefix)
+		_, _ = cb.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: fmt.Sprintf("🧹 Removed %d entries", removed)})
+	} else {
+		_, _ = cb.Answer(b, nil)
+	}
+
+	_, _, err := cb.Message.EditText(b, cacheText(), &gotgbot.EditMessageTextOpts{
+		ParseMode:   "HTML",
+		ReplyMarkup: cacheMarkup(),
+	})
+	if err != nil && strings.Contains(err.Error(), "message is not modified") {
+		return nil
+	}
+	return err
+}
@@ -14,17 +14,6 @@ import (
 
 const defaultPerPage = 5
 
-func ensureDev(b *gotgbot.Bot, ctx *ext.Context) bool {
-	if config.IsDev(ctx.EffectiveUser.Id) {
-		return true
-	}
-	_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{
-		Text:      "🚫 You are not authorized.",
-		ShowAlert: true,
-	})
-	return false
-}
-
 func parsePageFromCallback(data, prefix string) int {
 	trimmed := strings.TrimPrefix(data, prefix)
 	parts := strings.Split(strings.TrimPrefix(trimmed, ":"), ":")
@@ -110,7 +99,7 @@ func buildPaginationRow(prefix string, currentPage, totalPages int) []gotgbot.In
 }
 
 func listProjectsHandler(b *gotgbot.Bot, ctx *ext.Context) error {
-	if !ensureDev(b, ctx) {
+	if !ensureAllowed(b, ctx) {
 		return nil
 	}
 
@@ -118,9 +107,9 @@ func listProjectsHandler(b *gotgbot.Bot, ctx *ext.Context) error {
 	_, _ = cb.Answer(b, nil)
 
 	page := parsePageFromCallback(cb.Data, "list_projects")
-	result, err := config.Coolify.ListApplications(page, defaultPerPage)
+	result, err := config.Coolify.ListApplicationsWithContext(updateContext(ctx), page, defaultPerPage)
 	if err != nil {
-		_, _, err = cb.Message.EditText(b, "❌ Failed to fetch projects: "+err.Error(), nil)
+		_, _, err = cb.Message.EditText(b, "❌ Failed to fetch projects: "+errorText(err), nil)
 		return err
 	}
 
@@ -150,7 +139,7 @@ func listProjectsHandler(b *gotgbot.Bot, ctx *ext.Context) error {
 
 func projectMenuHandler(b *gotgbot.Bot, ctx *ext.Context) error {
 	cb := ctx.CallbackQuery
-	if !ensureDev(b, ctx) {
+	if !ensureAllowed(b, ctx) {
 		return nil
 	}
 	_, _ = cb.Answer(b, nil)
@@ -166,9 +155,9 @@ func projectMenuHandler(b *gotgbot.Bot, ctx *ext.Context) error {
 		}
 	}
 
-	app, err := config.Coolify.GetApplicationByUUID(uuid)
+	app, err := config.Coolify.GetApplicationByUUIDWithContext(updateContext(ctx), uuid)
 	if err != nil {
-		_, _, err = cb.Message.EditText(b, "❌ Failed to load project: "+err.Error(), nil)
+		_, _, err = cb.Message.EditText(b, "❌ Failed to load project: "+errorText(err), nil)
 		return err
 	}
 
@@ -177,6 +166,7 @@ func projectMenuHandler(b *gotgbot.Bot, ctx *ext.Context) error {
 		{{Text: "🔄 Restart", CallbackData: "restart:" + uuid}, {Text: "🚀 Deploy", CallbackData: "deploy:" + uuid}},
 		{{Text: "📜 Logs", CallbackData: "logs:" + uuid}, {Text: "ℹ️ Status", CallbackData: "status:" + uuid}},
 		{{Text: "🚚 Deployments", CallbackData: fmt.Sprintf("app_deployments:%s:%d", uuid, 1)}, {Text: "🌱 Envs", CallbackData: "app_envs:" + uuid}},
+		{{Text: "⚙️ Settings", CallbackData: "app_settings:" + uuid}},
 		{{Text: "🛑 Stop", CallbackData: "stop:" + uuid}, {Text: "❌ Delete", CallbackData: "delete:" + uuid}},
 		{{Text: "🔙 Back", CallbackData: fmt.Sprintf("list_projects:%d", fromPage)}},
 	}
@@ -189,7 +179,7 @@ func projectMenuHandler(b *gotgbot.Bot, ctx *ext.Context) error {
 }
 
 func projectDeploymentsHandler(b *gotgbot.Bot, ctx *ext.Context) error {
-	if !ensureDev(b, ctx) {
+	if !ensureAllowed(b, ctx) {
 		return nil
 	}
 	cb := ctx.CallbackQuery
@@ -208,9 +198,9 @@ func projectDeploymentsHandler(b *gotgbot.Bot, ctx *ext.Context) error {
 		}
 	}
 
-	result, err := config.Coolify.ListDeploymentsByApplication(uuid, page, defaultPerPage)
+	result, err := config.Coolify.ListDeploymentsByApplicationWithContext(updateContext(ctx), uuid, page, defaultPerPage)
 	if err != nil {
-		_, _, _ = cb.Message.EditText(b, "❌ Failed to fetch deployments: "+err.Error(), nil)
+		_, _, _ = cb.Message.EditText(b, "❌ Failed to fetch deployments: "+errorText(err), nil)
 		return err
 	}
 
@@ -223,8 +213,21 @@ func projectDeploymentsHandler(b *gotgbot.Bot, ctx *ext.Context) error {
 	currentPage, totalPages := derivePage(result.PageInfo(), len(deployments), defaultPerPage, page)
 
 	var sb strings.Builder
+	var rollbackRow, cancelRow []gotgbot.InlineKeyboardButton
 	sb.WriteString("<b>🚚 Deployments</b>\n")
 	for idx, d := range deployments {
+		if coolifyPkg.IsActiveDeploymentStatus(d.Status) {
+			cancelRow = append(cancelRow, gotgbot.InlineKeyboardButton{
+				Text:         fmt.Sprintf("✋ Cancel %d", idx+1),
+				CallbackData: cancelCallbackData(d.UUID, uuid),
+			})
+		}
+		if strings.EqualFold(d.Status, "finished") && d.Commit != "" && d.Commit != "HEAD" {
+			rollbackRow = append(rollbackRow, gotgbot.InlineKeyboardButton{
+				Text:         fmt.Sprintf("⏪ %d", idx+1),
+				CallbackData: fmt.Sprintf("rollback:%s:%s", uuid, d.UUID),
+			})
+		}
 		sb.WriteString(fmt.Sprintf("%d) <code>%s</code> — %s", idx+1, d.UUID, strings.ToUpper(d.Status)))
 		if d.Branch != "" {
 			sb.WriteString(fmt.Sprintf(" [%s]", d.Branch))
@@ -238,10 +241,18 @@ func projectDeploymentsHandler(b *gotgbot.Bot, ctx *ext.Context) error {
 		sb.WriteString("\n\n")
 	}
 
-	btns := [][]gotgbot.InlineKeyboardButton{
-		buildPaginationRow("app_deployments:"+uuid, currentPage, totalPages),
-		{{Text: "🔙 Back", CallbackData: "project_menu:" + uuid}},
+	var btns [][]gotgbot.InlineKeyboardButton
+	if len(cancelRow) > 0 {
+		btns = append(btns, cancelRow)
 	}
+	if len(rollbackRow) > 0 {
+		sb.WriteString("⏪ <i>Tap a number to redeploy that release.</i>")
+		btns = append(btns, rollbackRow)
+	}
+	btns = append(btns,
+		buildPaginationRow("app_deployments:"+uuid, currentPage, totalPages),
+		[]gotgbot.InlineKeyboardButton{{Text: "🔙 Back", CallbackData: "project_menu:" + uuid}},
+	)
 
 	_, _, err = cb.Message.EditText(b, sb.String(), &gotgbot.EditMessageTextOpts{
 		ParseMode:   "HTML",
@@ -251,16 +262,16 @@ func projectDeploymentsHandler(b *gotgbot.Bot, ctx *ext.Context) error {
 }
 
 func listDeploymentsHandler(b *gotgbot.Bot, ctx *ext.Context) error {
-	if !ensureDev(b, ctx) {
+	if !ensureAllowed(b, ctx) {
 		return nil
 	}
 	cb := ctx.CallbackQuery
 	_, _ = cb.Answer(b, nil)
 
 	page := parsePageFromCallback(cb.Data, "list_deployments")
-	result, err := config.Coolify.ListDeployments(page, defaultPerPage)
+	result, err := config.Coolify.ListDeploymentsWithContext(updateContext(ctx), page, defaultPerPage)
 	if err != nil {
-		_, _, _ = cb.Message.EditText(b, "❌ Failed to fetch deployments: "+err.Error(), nil)
+		_, _, _ = cb.Message.EditText(b, "❌ Failed to fetch deployments: "+errorText(err), nil)
 		return err
 	}
 
@@ -273,7 +284,14 @@ func listDeploymentsHandler(b *gotgbot.Bot, ctx *ext.Context) error {
 	currentPage, totalPages := derivePage(result.PageInfo(), len(items), defaultPerPage, page)
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("<b>🚚 Deployments (page %d/%d)</b>\n", currentPage, totalPages))
+	var cancelRow []gotgbot.InlineKeyboardButton
 	for idx, d := range items {
+		if coolifyPkg.IsActiveDeploymentStatus(d.Status) {
+			cancelRow = append(cancelRow, gotgbot.InlineKeyboardButton{
+				Text:         fmt.Sprintf("✋ Cancel %d", idx+1),
+				CallbackData: cancelCallbackData(d.UUID, ""),
+			})
+		}
 		sb.WriteString(fmt.Sprintf("%d) <code>%s</code> — %s", idx+1, d.UUID, strings.ToUpper(d.Status)))
 		if d.Application != "" {
 			sb.WriteString(fmt.Sprintf("\n    App: %s", html.EscapeString(d.Application)))
@@ -284,10 +302,14 @@ func listDeploymentsHandler(b *gotgbot.Bot, ctx *ext.Context) error {
 		sb.WriteString("\n\n")
 	}
 
-	btns := [][]gotgbot.InlineKeyboardButton{
-		buildPaginationRow("list_deployments", currentPage, totalPages),
-		{{Text: "🔙 Back", CallbackData: "list_projects:1"}},
+	var btns [][]gotgbot.InlineKeyboardButton
+	if len(cancelRow) > 0 {
+		btns = append(btns, cancelRow)
 	}
+	btns = append(btns,
+		buildPaginationRow("list_deployments", currentPage, totalPages),
+		[]gotgbot.InlineKeyboardButton{{Text: "🔙 Back", CallbackData: "list_projects:1"}},
+	)
 
 	_, _, err = cb.Message.EditText(b, sb.String(), &gotgbot.EditMessageTextOpts{
 		ParseMode:   "HTML",
@@ -297,16 +319,16 @@ func listDeploymentsHandler(b *gotgbot.Bot, ctx *ext.Context) error {
 }
 
 func listEnvironmentsHandler(b *gotgbot.Bot, ctx *ext.Context) error {
-	if !ensureDev(b, ctx) {
+	if !ensureAllowed(b, ctx) {
 		return nil
 	}
 	cb := ctx.CallbackQuery
 	_, _ = cb.Answer(b, nil)
 
 	page := parsePageFromCallback(cb.Data, "list_environments")
-	result, err := config.Coolify.ListEnvironments(page, defaultPerPage)
+	result, err := config.Coolify.ListEnvironmentsWithContext(updateContext(ctx), page, defaultPerPage)
 	if err != nil {
-		_, _, _ = cb.Message.EditText(b, "❌ Failed to fetch environments: "+err.Error(), nil)
+		_, _, _ = cb.Message.EditText(b, "❌ Failed to fetch environments: "+errorText(err), nil)
 		return err
 	}
 
@@ -327,11 +349,29 @@ func listEnvironmentsHandler(b *gotgbot.Bot, ctx *ext.Context) error {
 		sb.WriteString("\n")
 	}
 
-	btns := [][]gotgbot.InlineKeyboardButton{
-		buildPaginationRow("list_environments", currentPage, totalPages),
-		{{Text: "🔙 Back", CallbackData: "list_projects:1"}},
+	// Environments only carry the numeric project ID; map it to the UUID the drill-down needs.
+	projectUUIDs := make(map[int64]string)
+	if projects, err := config.Coolify.ListProjectsWithContext(updateContext(ctx)); err == nil {
+		for _, p := range projects {
+			projectUUIDs[p.ID] = p.UUID
+		}
 	}
 
+	var btns [][]gotgbot.InlineKeyboardButton
+	for idx, env := range items {
+		projectUUID, ok := projectUUIDs[env.ProjectID]
+		if !ok {
+			continue
+		}
+		btns = append(btns, []gotgbot.InlineKeyboardButton{
+			{Text: fmt.Sprintf("%d) 🌍 %s", idx+1, env.Name), CallbackData: fmt.Sprintf("browse_env:%s:%s", projectUUID, environmentKey(env))},
+		})
+	}
+	btns = append(btns,
+		buildPaginationRow("list_environments", currentPage, totalPages),
+		[]gotgbot.InlineKeyboardButton{{Text: "🔙 Back", CallbackData: "list_projects:1"}},
+	)
+
 	_, _, err = cb.Message.EditText(b, sb.String(), &gotgbot.EditMessageTextOpts{
 		ParseMode:   "HTML",
 		ReplyMarkup: gotgbot.InlineKeyboardMarkup{InlineKeyboard: btns},
@@ -340,16 +380,16 @@ func listEnvironmentsHandler(b *gotgbot.Bot, ctx *ext.Context) error {
 }
 
 func listDatabasesHandler(b *gotgbot.Bot, ctx *ext.Context) error {
-	if !ensureDev(b, ctx) {
+	if !ensureAllowed(b, ctx) {
 		return nil
 	}
 	cb := ctx.CallbackQuery
 	_, _ = cb.Answer(b, nil)
 
 	page := parsePageFromCallback(cb.Data, "list_databases")
-	result, err := config.Coolify.ListDatabases(page, defaultPerPage)
+	result, err := config.Coolify.ListDatabasesWithContext(updateContext(ctx), page, defaultPerPage)
 	if err != nil {
-		_, _, _ = cb.Message.EditText(b, "❌ Failed to fetch databases: "+err.Error(), nil)
+		_, _, _ = cb.Message.EditText(b, "❌ Failed to fetch databases: "+errorText(err), nil)
 		return err
 	}
 
@@ -373,10 +413,19 @@ func listDatabasesHandler(b *gotgbot.Bot, ctx *ext.Context) error {
 		sb.WriteString("\n")
 	}
 
-	btns := [][]gotgbot.InlineKeyboardButton{
-		buildPaginationRow("list_databases", currentPage, totalPages),
-		{{Text: "🔙 Back", CallbackData: "list_projects:1"}},
+	var btns [][]gotgbot.InlineKeyboardButton
+	for idx, db := range items {
+		if db.UUID == "" {
+			continue
+		}
+		btns = append(btns, []gotgbot.InlineKeyboardButton{
+			{Text: fmt.Sprintf("%d) %s", idx+1, db.Name), CallbackData: fmt.Sprintf("db_menu:%s:%d", db.UUID, currentPage)},
+		})
 	}
+	btns = append(btns,
+		buildPaginationRow("list_databases", currentPage, totalPages),
+		[]gotgbot.InlineKeyboardButton{{Text: "🔙 Back", CallbackData: "list_projects:1"}},
+	)
 
 	_, _, err = cb.Message.EditText(b, sb.String(), &gotgbot.EditMessageTextOpts{
 		ParseMode:   "HTML",
@@ -387,51 +436,59 @@ func listDatabasesHandler(b *gotgbot.Bot, ctx *ext.Context) error {
 
 func restartHandler(b *gotgbot.Bot, ctx *ext.Context) error {
 	cb := ctx.CallbackQuery
-	if !ensureDev(b, ctx) {
+	if !ensureAllowed(b, ctx) {
 		return nil
 	}
 	_, _ = cb.Answer(b, nil)
 
 	uuid := strings.TrimPrefix(cb.Data, "restart:")
-	res, err := config.Coolify.RestartApplicationByUUID(uuid)
+	res, err := config.Coolify.RestartApplicationByUUIDWithContext(updateContext(ctx), uuid)
+	recordAudit(b, ctx.EffectiveUser, "restart", uuid, "", deploymentMessage(res), err)
 	if err != nil {
-		_, _, err = cb.Message.EditText(b, "❌ Restart failed: "+err.Error(), nil)
+		_, _, err = cb.Message.EditText(b, "❌ Restart failed: "+errorText(err), nil)
 		return err
 	}
 	text := fmt.Sprintf("✅ Restart queued!\nDeployment UUID: <code>%s</code>", res.DeploymentUUID)
-	_, _, err = cb.Message.EditText(b, text, &gotgbot.EditMessageTextOpts{ParseMode: "HTML"})
-	return err
+	if _, _, err = cb.Message.EditText(b, text, &gotgbot.EditMessageTextOpts{ParseMode: "HTML"}); err != nil {
+		return err
+	}
+	trackDeployment(b, cb.Message.GetChat().Id, cb.Message.GetMessageId(), uuid, res.DeploymentUUID, "Restart")
+	return nil
 }
 
 func deployHandler(b *gotgbot.Bot, ctx *ext.Context) error {
 	cb := ctx.CallbackQuery
-	if !ensureDev(b, ctx) {
+	if !ensureAllowed(b, ctx) {
 		return nil
 	}
 	_, _ = cb.Answer(b, nil)
 
 	uuid := strings.TrimPrefix(cb.Data, "deploy:")
-	res, err := config.Coolify.StartApplicationDeployment(uuid, false, false)
+	res, err := config.Coolify.StartApplicationDeploymentWithContext(updateContext(ctx), uuid, false, false)
+	recordAudit(b, ctx.EffectiveUser, "deploy", uuid, "", deploymentMessage(res), err)
 	if err != nil {
-		_, _, err = cb.Message.EditText(b, "❌ Deploy failed: "+err.Error(), nil)
+		_, _, err = cb.Message.EditText(b, "❌ Deploy failed: "+errorText(err), nil)
 		return err
 	}
 	text := fmt.Sprintf("✅ Deployment queued!\nDeployment UUID: <code>%s</code>", res.DeploymentUUID)
-	_, _, err = cb.Message.EditText(b, text, &gotgbot.EditMessageTextOpts{ParseMode: "HTML"})
-	return err
+	if _, _, err = cb.Message.EditText(b, text, &gotgbot.EditMessageTextOpts{ParseMode: "HTML"}); err != nil {
+		return err
+	}
+	trackDeployment(b, cb.Message.GetChat().Id, cb.Message.GetMessageId(), uuid, res.DeploymentUUID, "Deployment")
+	return nil
 }
 
 func logsHandler(b *gotgbot.Bot, ctx *ext.Context) error {
 	cb := ctx.CallbackQuery
-	if !ensureDev(b, ctx) {
+	if !ensureAllowed(b, ctx) {
 		return nil
 	}
 	_, _ = cb.Answer(b, nil)
 
 	uuid := strings.TrimPrefix(cb.Data, "logs:")
-	logs, err := config.Coolify.GetApplicationLogsByUUID(uuid)
+	logs, err := config.Coolify.GetApplicationLogsByUUIDWithContext(updateContext(ctx), uuid, 0)
 	if err != nil {
-		_, _, _ = cb.Message.EditText(b, "❌ Logs error: "+err.Error(), nil)
+		_, _, _ = cb.Message.EditText(b, "❌ Logs error: "+errorText(err), nil)
 		return ext.EndGroups
 	}
 
@@ -444,15 +501,15 @@ func logsHandler(b *gotgbot.Bot, ctx *ext.Context) error {
 
 func statusHandler(b *gotgbot.Bot, ctx *ext.Context) error {
 	cb := ctx.CallbackQuery
-	if !ensureDev(b, ctx) {
+	if !ensureAllowed(b, ctx) {
 		return nil
 	}
 	_, _ = cb.Answer(b, nil)
 
 	uuid := strings.TrimPrefix(cb.Data, "status:")
-	app, err := config.Coolify.GetApplicationByUUID(uuid)
+	app, err := config.Coolify.GetApplicationByUUIDWithContext(updateContext(ctx), uuid)
 	if err != nil {
-		_, _, err = cb.Message.EditText(b, "❌ Status error: "+err.Error(), nil)
+		_, _, err = cb.Message.EditText(b, "❌ Status error: "+errorText(err), nil)
 		return nil
 	}
 
@@ -463,59 +520,87 @@ func statusHandler(b *gotgbot.Bot, ctx *ext.Context) error {
 
 func stopHandler(b *gotgbot.Bot, ctx *ext.Context) error {
 	cb := ctx.CallbackQuery
-	if !ensureDev(b, ctx) {
+	if !ensureAllowed(b, ctx) {
 		return nil
 	}
 	_, _ = cb.Answer(b, nil)
 
-	uuid := strings.TrimPrefix(cb.Data, "stop:")
-	res, err := config.Coolify.StopApplicationByUUID(uuid)
-	if err != nil {
-		_, _, err = cb.Message.EditText(b, "❌ Stop failed: "+err.Error(), nil)
-		return nil
-	}
-
-	_, _, err = cb.Message.EditText(b, "🛑 "+res.Message, nil)
-	return err
+	return askConfirmation(b, ctx, "stop", strings.TrimPrefix(cb.Data, "stop:"))
 }
 
 func deleteHandler(b *gotgbot.Bot, ctx *ext.Context) error {
 	cb := ctx.CallbackQuery
-	if !ensureDev(b, ctx) {
+	if !ensureAllowed(b, ctx) {
 		return nil
 	}
 	_, _ = cb.Answer(b, nil)
 
-	uuid := strings.TrimPrefix(cb.Data, "delete:")
-	err := config.Coolify.DeleteApplicationByUUID(uuid)
-	if err != nil {
-		_, _, err = cb.Message.EditText(b, "❌ Delete failed: "+err.Error(), nil)
-		return nil
-	}
-
-	_, _, err = cb.Message.EditText(b, "✅ Application deleted successfully.", nil)
-	return err
+	return askConfirmation(b, ctx, "delete", strings.TrimPrefix(cb.Data, "delete:"))
 }
 
 func appEnvsHandler(b *gotgbot.Bot, ctx *ext.Context) error {
 	cb := ctx.CallbackQuery
-	if !ensureDev(b, ctx) {
+	if !ensureAllowed(b, ctx) {
 		return nil
 	}
 	_, _ = cb.Answer(b, nil)
 
-	uuid := strings.TrimPrefix(cb.Data, "app_envs:")
-	envs, err := config.Coolify.GetApplicationEnvsByUUID(uuid)
+	parts := strings.Split(strings.TrimPrefix(cb.Data, "app_envs:"), ":")
+	uuid := parts[0]
+	page := 1
+	if len(parts) > 1 {
+		if p, err := strconv.Atoi(parts[1]); err == nil && p > 0 {
+			page = p
+		}
+	}
+
+	envs, err := config.Coolify.GetApplicationEnvsByUUIDWithContext(updateContext(ctx), uuid)
 	if err != nil {
-		_, _, _ = cb.Message.EditText(b, "❌ Failed to fetch env vars: "+err.Error(), nil)
+		_, _, _ = cb.Message.EditText(b, "❌ Failed to fetch env vars: "+errorText(err), nil)
 		return err
 	}
 
+	totalPages := maxInt(1, (len(envs)+envPerPage-1)/envPerPage)
+	page = minInt(page, totalPages)
+	start := (page - 1) * envPerPage
+	end := minInt(start+envPerPage, len(envs))
+
+	var btns [][]gotgbot.InlineKeyboardButton
+	for _, env := range envs[start:end] {
+		label := "🔑 " + env.Key
+		if env.IsBuildTime {
+			label += " 🏗"
+		}
+		btns = append(btns, []gotgbot.InlineKeyboardButton{
+			{Text: label, CallbackData: fmt.Sprintf("env_menu:%s:%s", uuid, env.UUID)},
+		})
+	}
+	if totalPages > 1 {
+		btns = append(btns, buildPaginationRow("app_envs:"+uuid, page, totalPages))
+	}
+	btns = append(btns,
+		[]gotgbot.InlineKeyboardButton{{Text: "➕ Add variable", CallbackData: "env_add:" + uuid}},
+		[]gotgbot.InlineKeyboardButton{
+			{Text: "📤 Export .env", CallbackData: "env_export:" + uuid},
+			{Text: "📤 Export masked", CallbackData: "env_export:" + uuid + ":m"},
+		},
+		[]gotgbot.InlineKeyboardButton{{Text: "📥 Import .env", CallbackData: "env_import:" + uuid}},
+		[]gotgbot.InlineKeyboardButton{{Text: "🔙 Back", CallbackData: "project_menu:" + uuid}},
+	)
+
+	text := fmt.Sprintf("<b>🌱 Environment Variables</b> (%d)\nTap a key to edit it. 🏗 = available at build time.", len(envs))
 	if len(envs) == 0 {
-		_, _, err = cb.Message.EditText(b, "This application has no environment variables.", nil)
-		return err
+		text = "This application has no environment variables."
 	}
 
+	_, _, err = cb.Message.EditText(b, text, &gotgbot.EditMessageTextOpts{
+		ParseMode:   "HTML",
+		ReplyMarkup: gotgbot.InlineKeyboardMarkup{InlineKeyboard: btns},
+	})
+	return err
+}
+
+func formatEnvList(envs []coolifyPkg.EnvironmentVariable) string {
 	var sb strings.Builder
 	sb.WriteString("<b>🌱 Environment Variables</b>\n")
 	limit := minInt(len(envs), 20)
@@ -526,16 +611,7 @@ func appEnvsHandler(b *gotgbot.Bot, ctx *ext.Context) error {
 	if len(envs) > limit {
 		sb.WriteString(fmt.Sprintf("\n…and %d more", len(envs)-limit))
 	}
-
-	btns := [][]gotgbot.InlineKeyboardButton{
-		{{Text: "🔙 Back", CallbackData: "project_menu:" + uuid}},
-	}
-
-	_, _, err = cb.Message.EditText(b, sb.String(), &gotgbot.EditMessageTextOpts{
-		ParseMode:   "HTML",
-		ReplyMarkup: gotgbot.InlineKeyboardMarkup{InlineKeyboard: btns},
-	})
-	return err
+	return sb.String()
 }
 
 func maxInt(a, b int) int {
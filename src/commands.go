@@ -0,0 +1,327 @@
+package src
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"coolifymanager/src/config"
+	coolifyPkg "coolifymanager/src/coolity"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+)
+
+const maxListedMatches = 15
+
+var botCommands = []gotgbot.BotCommand{
+	{Command: "start", Description: "Open the main menu"},
+	{Command: "apps", Description: "List applications: /apps [filter]"},
+	{Command: "deploy", Description: "Deploy an app: /deploy <name|uuid> [--force]"},
+	{Command: "restart", Description: "Restart an app: /restart <name|uuid>"},
+	{Command: "stop", Description: "Stop an app: /stop <name|uuid>"},
+	{Command: "logs", Description: "Fetch logs: /logs <name|uuid> [lines]"},
+	{Command: "status", Description: "Show status: /status <name|uuid>"},
+	{Command: "envs", Description: "List env keys: /envs <name|uuid>"},
+	{Command: "newapp", Description: "Create an application step by step"},
+	{Command: "schedule", Description: "Schedule deploy/restart/stop: /schedule add|list|remove"},
+	{Command: "subscribe", Description: "Alert this chat on health changes: /subscribe <app>"},
+	{Command: "unsubscribe", Description: "Stop health alerts: /unsubscribe <app>"},
+	{Command: "ping", Description: "Check bot latency"},
+	{Command: "version", Description: "Show the detected Coolify and API version"},
+}
+
+// SetCommands registers the slash commands with Telegram so clients can autocomplete them.
+func SetCommands(b *gotgbot.Bot) {
+	if _, err := b.SetMyCommands(botCommands, nil); err != nil {
+		log.Printf("failed to set bot commands: %v", err)
+	}
+}
+
+func commandArgs(msg *gotgbot.Message) []string {
+	fields := strings.Fields(msg.Text)
+	if len(fields) == 0 {
+		return nil
+	}
+	return fields[1:]
+}
+
+func replyHTML(b *gotgbot.Bot, msg *gotgbot.Message, text string, markup *gotgbot.InlineKeyboardMarkup) (*gotgbot.Message, error) {
+	opts := &gotgbot.SendMessageOpts{
+		ParseMode:          "HTML",
+		LinkPreviewOptions: &gotgbot.LinkPreviewOptions{IsDisabled: true},
+	}
+	if markup != nil {
+		opts.ReplyMarkup = *markup
+	}
+	return msg.Reply(b, text, opts)
+}
+
+func allApplications(reqCtx context.Context) ([]coolifyPkg.Application, error) {
+	result, err := config.Coolify.ListApplicationsWithContext(reqCtx, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	return result.Results(), nil
+}
+
+// matchApplications ranks applications against query and returns the best tier:
+// exact UUID or name, then name prefix, then substring, then subsequence.
+func matchApplications(apps []coolifyPkg.Application, query string) []coolifyPkg.Application {
+	q := strings.ToLower(strings.TrimSpace(query))
+	if q == "" {
+		return apps
+	}
+
+	tiers := make([][]coolifyPkg.Application, 4)
+	for _, app := range apps {
+		name := strings.ToLower(app.Name)
+		switch {
+		case app.UUID == query || name == q:
+			tiers[0] = append(tiers[0], app)
+		case strings.HasPrefix(name, q):
+			tiers[1] = append(tiers[1], app)
+		case strings.Contains(name, q):
+			tiers[2] = append(tiers[2], app)
+		case isSubsequence(q, name):
+			tiers[3] = append(tiers[3], app)
+		}
+	}
+
+	for _, tier := range tiers {
+		if len(tier) > 0 {
+			sort.Slice(tier, func(i, j int) bool { return tier[i].Name < tier[j].Name })
+			return tier
+		}
+	}
+	return nil
+}
+
+func isSubsequence(needle, haystack string) bool {
+	hr := []rune(haystack)
+	i := 0
+	for _, r := range needle {
+		for i < len(hr) && hr[i] != r {
+			i++
+		}
+		if i == len(hr) {
+			return false
+		}
+		i++
+	}
+	return true
+}
+
+func appButtons(apps []coolifyPkg.Application) [][]gotgbot.InlineKeyboardButton {
+	var rows [][]gotgbot.InlineKeyboardButton
+	for i, app := range apps {
+		if i == maxListedMatches {
+			break
+		}
+		rows = append(rows, []gotgbot.InlineKeyboardButton{
+			{Text: fmt.Sprintf("📦 %s (%s)", app.Name, app.Status), CallbackData: "project_menu:" + app.UUID},
+		})
+	}
+	return rows
+}
+
+// resolveAppCommand finds the single application a command refers to. It replies to the
+// user and returns nil when the query is missing, unknown, ambiguous or not permitted.
+func resolveAppCommand(b *gotgbot.Bot, ctx *ext.Context, action, usage string) (*coolifyPkg.Application, []string) {
+	msg := ctx.EffectiveMessage
+	args := commandArgs(msg)
+	if len(args) == 0 {
+		_, _ = replyHTML(b, msg, "Usage: <code>"+html.EscapeString(usage)+"</code>", nil)
+		return nil, nil
+	}
+
+	apps, err := allApplications(updateContext(ctx))
+	if err != nil {
+		_, _ = msg.Reply(b, "❌ Failed to fetch projects: "+errorText(err), nil)
+		return nil, nil
+	}
+
+	matches := matchApplications(apps, args[0])
+	switch {
+	case len(matches) == 0:
+		_, _ = replyHTML(b, msg, fmt.Sprintf("😶 No application matches <code>%s</code>.", html.EscapeString(args[0])), nil)
+		return nil, nil
+	case len(matches) > 1:
+		markup := gotgbot.InlineKeyboardMarkup{InlineKeyboard: appButtons(matches)}
+		_, _ = replyHTML(b, msg, fmt.Sprintf("🤔 <code>%s</code> matches %d applications. Pick one or be more specific:", html.EscapeString(args[0]), len(matches)), &markup)
+		return nil, nil
+	}
+
+	app := matches[0]
+	if !isAllowed(updateContext(ctx), ctx.EffectiveUser.Id, action, app.UUID) {
+		_, _ = msg.Reply(b, "🚫 You are not authorized.", nil)
+		return nil, nil
+	}
+	return &app, args[1:]
+}
+
+func appsCommandHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+	if !isAllowed(updateContext(ctx), ctx.EffectiveUser.Id, "list_projects", "") {
+		_, err := msg.Reply(b, "🚫 You are not authorized.", nil)
+		return err
+	}
+
+	apps, err := allApplications(updateContext(ctx))
+	if err != nil {
+		_, err = msg.Reply(b, "❌ Failed to fetch projects: "+errorText(err), nil)
+		return err
+	}
+
+	filter := strings.Join(commandArgs(msg), " ")
+	matches := matchApplications(apps, filter)
+	if len(matches) == 0 {
+		_, err = msg.Reply(b, "😶 No applications found.", nil)
+		return err
+	}
+
+	text := fmt.Sprintf("<b>📋 Applications</b> (%d)", len(matches))
+	if len(matches) > maxListedMatches {
+		text += fmt.Sprintf("\nShowing the first %d, narrow it down with <code>/apps &lt;filter&gt;</code>.", maxListedMatches)
+	}
+	markup := gotgbot.InlineKeyboardMarkup{InlineKeyboard: appButtons(matches)}
+	_, err = replyHTML(b, msg, text, &markup)
+	return err
+}
+
+func deployCommandHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	app, rest := resolveAppCommand(b, ctx, "deploy", "/deploy <name|uuid> [--force]")
+	if app == nil {
+		return nil
+	}
+
+	force := false
+	for _, arg := range rest {
+		if arg == "--force" || arg == "-f" {
+			force = true
+		}
+	}
+
+	msg := ctx.EffectiveMessage
+	res, err := config.Coolify.StartApplicationDeploymentWithContext(updateContext(ctx), app.UUID, force, false)
+	recordAudit(b, ctx.EffectiveUser, "deploy", app.UUID, app.Name, deploymentMessage(res), err)
+	if err != nil {
+		_, err = msg.Reply(b, "❌ Deploy failed: "+errorText(err), nil)
+		return err
+	}
+
+	text := fmt.Sprintf("✅ Deployment queued!\nDeployment UUID: <code>%s</code>", res.DeploymentUUID)
+	sent, err := replyHTML(b, msg, text, nil)
+	if err != nil {
+		return err
+	}
+	trackDeployment(b, sent.Chat.Id, sent.MessageId, app.UUID, res.DeploymentUUID, "Deployment")
+	return nil
+}
+
+func restartCommandHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	app, _ := resolveAppCommand(b, ctx, "restart", "/restart <name|uuid>")
+	if app == nil {
+		return nil
+	}
+
+	msg := ctx.EffectiveMessage
+	res, err := config.Coolify.RestartApplicationByUUIDWithContext(updateContext(ctx), app.UUID)
+	recordAudit(b, ctx.EffectiveUser, "restart", app.UUID, app.Name, deploymentMessage(res), err)
+	if err != nil {
+		_, err = msg.Reply(b, "❌ Restart failed: "+errorText(err), nil)
+		return err
+	}
+
+	text := fmt.Sprintf("✅ Restart queued!\nDeployment UUID: <code>%s</code>", res.DeploymentUUID)
+	sent, err := replyHTML(b, msg, text, nil)
+	if err != nil {
+		return err
+	}
+	trackDeployment(b, sent.Chat.Id, sent.MessageId, app.UUID, res.DeploymentUUID, "Restart")
+	return nil
+}
+
+func stopCommandHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	app, _ := resolveAppCommand(b, ctx, "stop", "/stop <name|uuid>")
+	if app == nil {
+		return nil
+	}
+
+	msg := ctx.EffectiveMessage
+	text, markup, err := confirmationPrompt(updateContext(ctx), "stop", app.UUID, ctx.EffectiveUser.Id)
+	if err != nil {
+		_, err = msg.Reply(b, "❌ Failed to load resource: "+errorText(err), nil)
+		return err
+	}
+	_, err = replyHTML(b, msg, text, &markup)
+	return err
+}
+
+func logsCommandHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	app, rest := resolveAppCommand(b, ctx, "logs", "/logs <name|uuid> [lines]")
+	if app == nil {
+		return nil
+	}
+
+	lines := 0
+	if len(rest) > 0 {
+		if n, err := strconv.Atoi(rest[0]); err == nil && n > 0 {
+			lines = n
+		}
+	}
+
+	msg := ctx.EffectiveMessage
+	logs, err := config.Coolify.GetApplicationLogsByUUIDWithContext(updateContext(ctx), app.UUID, lines)
+	if err != nil {
+		_, err = msg.Reply(b, "❌ Logs error: "+errorText(err), nil)
+		return err
+	}
+	_, err = replyHTML(b, msg, fmt.Sprintf("<b>📜 Logs — %s</b>\n%s", html.EscapeString(app.Name), html.EscapeString(logs)), nil)
+	return err
+}
+
+func statusCommandHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	app, _ := resolveAppCommand(b, ctx, "status", "/status <name|uuid>")
+	if app == nil {
+		return nil
+	}
+
+	msg := ctx.EffectiveMessage
+	detail, err := config.Coolify.GetApplicationByUUIDWithContext(updateContext(ctx), app.UUID)
+	if err != nil {
+		_, err = msg.Reply(b, "❌ Status error: "+errorText(err), nil)
+		return err
+	}
+
+	text := fmt.Sprintf("📦 <b>%s</b>\n🌐 %s\nCurrent Status: <code>%s</code>", html.EscapeString(detail.Name), html.EscapeString(detail.FQDN), detail.Status)
+	markup := gotgbot.InlineKeyboardMarkup{InlineKeyboard: [][]gotgbot.InlineKeyboardButton{
+		{{Text: "📦 Open menu", CallbackData: "project_menu:" + app.UUID}},
+	}}
+	_, err = replyHTML(b, msg, text, &markup)
+	return err
+}
+
+func envsCommandHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	app, _ := resolveAppCommand(b, ctx, "app_envs", "/envs <name|uuid>")
+	if app == nil {
+		return nil
+	}
+
+	msg := ctx.EffectiveMessage
+	envs, err := config.Coolify.GetApplicationEnvsByUUIDWithContext(updateContext(ctx), app.UUID)
+	if err != nil {
+		_, err = msg.Reply(b, "❌ Failed to fetch env vars: "+errorText(err), nil)
+		return err
+	}
+	if len(envs) == 0 {
+		_, err = msg.Reply(b, "This application has no environment variables.", nil)
+		return err
+	}
+	_, err = replyHTML(b, msg, formatEnvList(envs), nil)
+	return err
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+const bucketAudit = "audit"
+
+// AuditEntry records one mutating action and who triggered it.
+type AuditEntry struct {
+	Time       time.Time `json:"time"`
+	UserID     int64     `json:"user_id"`
+	UserName   string    `json:"user_name"`
+	Action     string    `json:"action"`
+	Target     string    `json:"target"`
+	TargetName string    `json:"target_name,omitempty"`
+	OK         bool      `json:"ok"`
+	Message    string    `json:"message,omitempty"`
+}
+
+var (
+	auditMaxEntries = 1000
+	auditMirror     = os.Getenv("AUDIT_MIRROR")
+)
+
+func loadAuditSettings() {
+	if raw := os.Getenv("AUDIT_MAX_ENTRIES"); raw != "" {
+		if n, err := strconv.Atoi(raw); err == nil && n > 0 {
+			auditMaxEntries = n
+		}
+	}
+}
+
+// AuditMirror reports whether audit entries are also posted to LOG_ID.
+func AuditMirror() bool {
+	v, _ := strconv.ParseBool(auditMirror)
+	return v && logChatID != 0
+}
+
+// RecordAudit stores e, dropping the oldest entries beyond AUDIT_MAX_ENTRIES.
+func RecordAudit(e AuditEntry) error {
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	// Zero-padded nanoseconds keep keys in chronological order.
+	key := fmt.Sprintf("%020d", e.Time.UnixNano())
+	return Store.Append(bucketAudit, key, e, auditMaxEntries)
+}
+
+// QueryAudit returns up to limit entries accepted by match, newest first.
+func QueryAudit(match func(AuditEntry) bool, limit int) ([]AuditEntry, error) {
+	var all []AuditEntry
+	err := loadBucket(bucketAudit, func(_ string, e AuditEntry) {
+		if match == nil || match(e) {
+			all = append(all, e)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]AuditEntry, 0, limit)
+	for i := len(all) - 1; i >= 0 && len(out) < limit; i-- {
+		out = append(out, all[i])
+	}
+	return out, nil
+}
@@ -1,7 +1,9 @@
 package config
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
@@ -23,17 +25,28 @@ var (
 	WebhookUrl = os.Getenv("WEBHOOK_URL")
 	LogID      = os.Getenv("LOG_ID")
 	DebugAPI   = os.Getenv("DEBUG_COOLIFY")
-	devList    = os.Getenv("DEV_IDS") // comma-separated
-	devIDs     []int64                // parsed slice
-	logChatID  int64
-	apiVersion string
+
+	CoolifyWebhookSecret = os.Getenv("COOLIFY_WEBHOOK_SECRET")
+	CoolifyWebhookPath   = os.Getenv("COOLIFY_WEBHOOK_PATH")
+	coolifyWebhookChats  = os.Getenv("COOLIFY_WEBHOOK_CHATS") // comma-separated
+	notifyChatIDs        []int64
+	devList              = os.Getenv("DEV_IDS") // comma-separated
+	devIDs               []int64                // parsed slice
+	logChatID            int64
+	baseCtx              = context.Background()
+	apiVersion           string
+
+	watchInterval = 60 * time.Second
 )
 
-func Init() error {
+// Init loads the configuration and builds the Coolify client. Cancelling ctx
+// aborts every Coolify request still in flight.
+func Init(ctx context.Context) error {
 	if ApiUrl == "" || ApiToken == "" {
 		return errors.New("API_URL and API_TOKEN must be set")
 	}
 
+	baseCtx = ctx
 	ApiUrl = sanitizeBaseURL(ApiUrl)
 	apiVersion = resolveAPIVersion(ApiVersion)
 
@@ -43,14 +56,46 @@ func Init() error {
 			cacheTTL = time.Duration(sec) * time.Second
 		}
 	}
+	cacheEntries := 0
+	if raw := os.Getenv("CACHE_MAX_ENTRIES"); raw != "" {
+		if n, err := strconv.Atoi(raw); err == nil && n > 0 {
+			cacheEntries = n
+		} else {
+			log.Printf("CACHE_MAX_ENTRIES is not a valid number: %s", raw)
+		}
+	}
+
+	// API_TIMEOUT_SECONDS bounds a whole call, including retries
+	requestTimeout := 30 * time.Second
+	if raw := os.Getenv("API_TIMEOUT_SECONDS"); raw != "" {
+		if sec, err := strconv.Atoi(raw); err == nil && sec > 0 {
+			requestTimeout = time.Duration(sec) * time.Second
+		} else {
+			log.Printf("API_TIMEOUT_SECONDS is not a valid number: %s", raw)
+		}
+	}
+
+	// API_RETRY_ATTEMPTS=1 disables retries of transient failures
+	retry := coolify.DefaultRetryPolicy
+	if raw := os.Getenv("API_RETRY_ATTEMPTS"); raw != "" {
+		if n, err := strconv.Atoi(raw); err == nil && n > 0 {
+			retry.MaxAttempts = n
+		} else {
+			log.Printf("API_RETRY_ATTEMPTS is not a valid number: %s", raw)
+		}
+	}
 
 	Coolify = coolify.NewClient(
 		ApiUrl,
 		ApiToken,
 		coolify.WithAPIVersion(apiVersion),
 		coolify.WithCacheTTL(cacheTTL),
+		coolify.WithCache(coolify.NewMemoryCache().WithMaxEntries(cacheEntries)),
 		coolify.WithHTTPClient(&http.Client{Timeout: 10 * time.Second}),
 		coolify.WithDebug(DebugAPI == "1" || strings.ToLower(DebugAPI) == "true"),
+		coolify.WithBaseContext(ctx),
+		coolify.WithRequestTimeout(requestTimeout),
+		coolify.WithRetryPolicy(retry),
 	)
 
 	// Parse DEV_IDS
@@ -67,6 +112,29 @@ func Init() error {
 		}
 	}
 
+	if err := openStore(); err != nil {
+		return fmt.Errorf("open storage %s: %w", storagePath, err)
+	}
+	if err := loadRoles(); err != nil {
+		log.Printf("Failed to load roles: %v", err)
+	}
+	if err := loadSubscriptions(); err != nil {
+		log.Printf("Failed to load subscriptions: %v", err)
+	}
+	if err := loadSchedules(); err != nil {
+		log.Printf("Failed to load schedules: %v", err)
+	}
+	loadAuditSettings()
+
+	// WATCH_INTERVAL_SECONDS=0 disables the status watcher
+	if raw := os.Getenv("WATCH_INTERVAL_SECONDS"); raw != "" {
+		if sec, err := strconv.Atoi(raw); err == nil && sec >= 0 {
+			watchInterval = time.Duration(sec) * time.Second
+		} else {
+			log.Printf("WATCH_INTERVAL_SECONDS is not a valid number: %s", raw)
+		}
+	}
+
 	// Parse LOG_ID
 	if LogID != "" {
 		if id, err := strconv.ParseInt(LogID, 10, 64); err == nil {
@@ -76,6 +144,25 @@ func Init() error {
 		}
 	}
 
+	// Parse COOLIFY_WEBHOOK_CHATS
+	for _, idStr := range strings.Split(coolifyWebhookChats, ",") {
+		idStr = strings.TrimSpace(idStr)
+		if idStr == "" {
+			continue
+		}
+		if id, err := strconv.ParseInt(idStr, 10, 64); err == nil {
+			notifyChatIDs = append(notifyChatIDs, id)
+		} else {
+			log.Printf("COOLIFY_WEBHOOK_CHATS entry is not an integer: %s", idStr)
+		}
+	}
+	if CoolifyWebhookPath == "" {
+		CoolifyWebhookPath = "/coolify/webhook"
+	}
+	if !strings.HasPrefix(CoolifyWebhookPath, "/") {
+		CoolifyWebhookPath = "/" + CoolifyWebhookPath
+	}
+
 	return nil
 }
 
@@ -100,10 +187,32 @@ func resolveAPIVersion(version string) string {
 	return version
 }
 
+// Context is the context given to Init; it is cancelled when the bot shuts down.
+func Context() context.Context {
+	return baseCtx
+}
+
 func LogChat() int64 {
 	return logChatID
 }
 
+// NotifyChats returns the chats that receive Coolify webhook notifications,
+// falling back to the log chat when COOLIFY_WEBHOOK_CHATS is empty.
+func NotifyChats() []int64 {
+	if len(notifyChatIDs) > 0 {
+		return notifyChatIDs
+	}
+	if logChatID != 0 {
+		return []int64{logChatID}
+	}
+	return nil
+}
+
+// WatchInterval is how often the status watcher polls Coolify; zero means disabled.
+func WatchInterval() time.Duration {
+	return watchInterval
+}
+
 func sanitizeBaseURL(raw string) string {
 	raw = strings.TrimSpace(raw)
 	raw = strings.TrimSuffix(raw, "/")
@@ -0,0 +1,19 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+)
+
+// readJSONFile decodes path into v. A missing file is not an error.
+func readJSONFile(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,230 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Role is a permission level; higher roles include everything lower roles may do.
+type Role int
+
+const (
+	RoleNone Role = iota
+	RoleViewer
+	RoleDeployer
+	RoleAdmin
+)
+
+func (r Role) String() string {
+	switch r {
+	case RoleViewer:
+		return "viewer"
+	case RoleDeployer:
+		return "deployer"
+	case RoleAdmin:
+		return "admin"
+	default:
+		return "none"
+	}
+}
+
+func ParseRole(s string) (Role, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "viewer":
+		return RoleViewer, true
+	case "deployer":
+		return RoleDeployer, true
+	case "admin":
+		return RoleAdmin, true
+	}
+	return RoleNone, false
+}
+
+// Grant gives a user a role, either globally (empty Scope) or for a single
+// application ("app:<uuid>") or Coolify environment ("env:<name or id>").
+type Grant struct {
+	UserID int64  `json:"user_id"`
+	Role   string `json:"role"`
+	Scope  string `json:"scope,omitempty"`
+}
+
+var (
+	rolesMu sync.RWMutex
+	grants  []Grant
+)
+
+// NormalizeScope validates a scope string and returns it in canonical form.
+func NormalizeScope(scope string) (string, error) {
+	scope = strings.TrimSpace(scope)
+	if scope == "" || strings.EqualFold(scope, "global") {
+		return "", nil
+	}
+	kind, value, ok := strings.Cut(scope, ":")
+	kind = strings.ToLower(kind)
+	if !ok || value == "" || (kind != "app" && kind != "env") {
+		return "", fmt.Errorf("invalid scope %q: use app:<uuid> or env:<name>", scope)
+	}
+	return kind + ":" + value, nil
+}
+
+func loadRoles() error {
+	rolesMu.Lock()
+	defer rolesMu.Unlock()
+
+	grants = nil
+	return loadBucket(bucketRoles, func(_ string, g Grant) {
+		grants = append(grants, g)
+	})
+}
+
+// GrantRole assigns role to userID within scope, replacing any existing grant for that scope.
+func GrantRole(userID int64, role Role, scope string) error {
+	scope, err := NormalizeScope(scope)
+	if err != nil {
+		return err
+	}
+
+	rolesMu.Lock()
+	defer rolesMu.Unlock()
+
+	grant := Grant{UserID: userID, Role: role.String(), Scope: scope}
+	if err := Store.Put(bucketRoles, grantKey(grant), grant); err != nil {
+		return err
+	}
+	for i, g := range grants {
+		if g.UserID == userID && g.Scope == scope {
+			grants[i] = grant
+			return nil
+		}
+	}
+	grants = append(grants, grant)
+	return nil
+}
+
+// RevokeRole removes the user's grant for scope, or every grant when scope is "all".
+func RevokeRole(userID int64, scope string) (int, error) {
+	all := strings.EqualFold(strings.TrimSpace(scope), "all")
+	if !all {
+		var err error
+		if scope, err = NormalizeScope(scope); err != nil {
+			return 0, err
+		}
+	}
+
+	rolesMu.Lock()
+	defer rolesMu.Unlock()
+
+	kept := grants[:0]
+	removed := 0
+	var err error
+	for _, g := range grants {
+		if g.UserID == userID && (all || g.Scope == scope) && err == nil {
+			if err = Store.Delete(bucketRoles, grantKey(g)); err == nil {
+				removed++
+				continue
+			}
+		}
+		kept = append(kept, g)
+	}
+	grants = kept
+	return removed, err
+}
+
+// Grants returns a sorted copy of every runtime grant.
+func Grants() []Grant {
+	rolesMu.RLock()
+	defer rolesMu.RUnlock()
+
+	out := append([]Grant(nil), grants...)
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].UserID != out[j].UserID {
+			return out[i].UserID < out[j].UserID
+		}
+		return out[i].Scope < out[j].Scope
+	})
+	return out
+}
+
+// GlobalRole returns the role a user holds everywhere. DEV_IDS are always admins.
+func GlobalRole(userID int64) Role {
+	if IsDev(userID) {
+		return RoleAdmin
+	}
+
+	rolesMu.RLock()
+	defer rolesMu.RUnlock()
+
+	best := RoleNone
+	for _, g := range grants {
+		if g.UserID == userID && g.Scope == "" {
+			if r, _ := ParseRole(g.Role); r > best {
+				best = r
+			}
+		}
+	}
+	return best
+}
+
+// HighestRole returns the strongest role a user holds in any scope.
+func HighestRole(userID int64) Role {
+	best := GlobalRole(userID)
+
+	rolesMu.RLock()
+	defer rolesMu.RUnlock()
+	for _, g := range grants {
+		if g.UserID == userID {
+			if r, _ := ParseRole(g.Role); r > best {
+				best = r
+			}
+		}
+	}
+	return best
+}
+
+// HasEnvScopedGrant reports whether resolving a resource's environment could change the user's role.
+func HasEnvScopedGrant(userID int64) bool {
+	rolesMu.RLock()
+	defer rolesMu.RUnlock()
+	for _, g := range grants {
+		if g.UserID == userID && strings.HasPrefix(g.Scope, "env:") {
+			return true
+		}
+	}
+	return false
+}
+
+// RoleFor returns the strongest role that applies to a resource. envKeys are the
+// identifiers (name, id, uuid) of the resource's environment, if known.
+func RoleFor(userID int64, resourceUUID string, envKeys ...string) Role {
+	best := GlobalRole(userID)
+
+	rolesMu.RLock()
+	defer rolesMu.RUnlock()
+	for _, g := range grants {
+		if g.UserID != userID || g.Scope == "" {
+			continue
+		}
+		kind, value, _ := strings.Cut(g.Scope, ":")
+		matched := false
+		switch kind {
+		case "app":
+			matched = resourceUUID != "" && value == resourceUUID
+		case "env":
+			for _, key := range envKeys {
+				if key != "" && strings.EqualFold(key, value) {
+					matched = true
+					break
+				}
+			}
+		}
+		if !matched {
+			continue
+		}
+		if r, _ := ParseRole(g.Role); r > best {
+			best = r
+		}
+	}
+	return best
+}
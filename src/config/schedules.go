@@ -0,0 +1,96 @@
+package config
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// Schedule is a recurring action the bot runs against an application.
+type Schedule struct {
+	ID        string `json:"id"`
+	Spec      string `json:"spec"`
+	Action    string `json:"action"`
+	AppUUID   string `json:"app_uuid"`
+	AppName   string `json:"app_name"`
+	ChatID    int64  `json:"chat_id"`
+	CreatedBy int64  `json:"created_by"`
+}
+
+var (
+	schedulesMu sync.RWMutex
+	schedules   []Schedule
+)
+
+func loadSchedules() error {
+	schedulesMu.Lock()
+	defer schedulesMu.Unlock()
+
+	schedules = nil
+	err := loadBucket(bucketSchedules, func(_ string, s Schedule) {
+		schedules = append(schedules, s)
+	})
+	// Keys sort as strings; keep schedules in creation order.
+	sort.Slice(schedules, func(i, j int) bool {
+		a, _ := strconv.Atoi(schedules[i].ID)
+		b, _ := strconv.Atoi(schedules[j].ID)
+		return a < b
+	})
+	return err
+}
+
+// AddSchedule stores s under the next free numeric ID and returns the stored copy.
+func AddSchedule(s Schedule) (Schedule, error) {
+	schedulesMu.Lock()
+	defer schedulesMu.Unlock()
+
+	next := 1
+	for _, existing := range schedules {
+		if id, err := strconv.Atoi(existing.ID); err == nil && id >= next {
+			next = id + 1
+		}
+	}
+	s.ID = strconv.Itoa(next)
+	if err := Store.Put(bucketSchedules, s.ID, s); err != nil {
+		return Schedule{}, err
+	}
+	schedules = append(schedules, s)
+	return s, nil
+}
+
+// RemoveSchedule deletes a schedule by ID. It reports false if no schedule had that ID.
+func RemoveSchedule(id string) (bool, error) {
+	schedulesMu.Lock()
+	defer schedulesMu.Unlock()
+
+	for i, s := range schedules {
+		if s.ID == id {
+			if err := Store.Delete(bucketSchedules, id); err != nil {
+				return false, err
+			}
+			schedules = append(schedules[:i], schedules[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// GetSchedule returns the schedule with the given ID.
+func GetSchedule(id string) (Schedule, bool) {
+	schedulesMu.RLock()
+	defer schedulesMu.RUnlock()
+
+	for _, s := range schedules {
+		if s.ID == id {
+			return s, true
+		}
+	}
+	return Schedule{}, false
+}
+
+// Schedules returns a copy of all schedules.
+func Schedules() []Schedule {
+	schedulesMu.RLock()
+	defer schedulesMu.RUnlock()
+	return append([]Schedule(nil), schedules...)
+}
@@ -0,0 +1,104 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"strconv"
+
+	"coolifymanager/src/storage"
+)
+
+const (
+	bucketRoles         = "roles"
+	bucketSubscriptions = "subscriptions"
+	bucketSchedules     = "schedules"
+)
+
+var (
+	// Store holds durable bot state. It is opened by Init.
+	Store         storage.Store
+	storageDriver = os.Getenv("STORAGE_DRIVER")
+	storagePath   = os.Getenv("STORAGE_PATH")
+
+	// Files written by releases before the storage layer; imported once by migration 1.
+	legacyRolesPath         = os.Getenv("ROLES_FILE")
+	legacySubscriptionsPath = os.Getenv("SUBSCRIPTIONS_FILE")
+	legacySchedulesPath     = os.Getenv("SCHEDULES_FILE")
+)
+
+var migrations = []storage.Migration{
+	{Version: 1, Name: "import legacy JSON files", Up: importLegacyFiles},
+}
+
+func openStore() error {
+	if storagePath == "" {
+		storagePath = "coolifybot.json"
+	}
+
+	s, err := storage.Open(storageDriver, storagePath)
+	if err != nil {
+		return err
+	}
+	if err := storage.Migrate(s, migrations); err != nil {
+		return err
+	}
+	Store = s
+	return nil
+}
+
+func orDefault(v, fallback string) string {
+	if v == "" {
+		return fallback
+	}
+	return v
+}
+
+// importLegacyFiles copies roles.json, subscriptions.json and schedules.json into the store.
+func importLegacyFiles(s storage.Store) error {
+	var legacyGrants []Grant
+	if err := readJSONFile(orDefault(legacyRolesPath, "roles.json"), &legacyGrants); err != nil {
+		return err
+	}
+	for _, g := range legacyGrants {
+		if err := s.Put(bucketRoles, grantKey(g), g); err != nil {
+			return err
+		}
+	}
+
+	legacySubs := make(map[string][]int64)
+	if err := readJSONFile(orDefault(legacySubscriptionsPath, "subscriptions.json"), &legacySubs); err != nil {
+		return err
+	}
+	for uuid, chats := range legacySubs {
+		if err := s.Put(bucketSubscriptions, uuid, chats); err != nil {
+			return err
+		}
+	}
+
+	var legacySchedules []Schedule
+	if err := readJSONFile(orDefault(legacySchedulesPath, "schedules.json"), &legacySchedules); err != nil {
+		return err
+	}
+	for _, sched := range legacySchedules {
+		if err := s.Put(bucketSchedules, sched.ID, sched); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func grantKey(g Grant) string {
+	return strconv.FormatInt(g.UserID, 10) + "|" + g.Scope
+}
+
+// loadBucket decodes every value of bucket and passes it to fn.
+func loadBucket[T any](bucket string, fn func(key string, v T)) error {
+	return Store.ForEach(bucket, func(key string, raw []byte) error {
+		var v T
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return err
+		}
+		fn(key, v)
+		return nil
+	})
+}
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"sync"
+)
+
+var (
+	subsMu        sync.RWMutex
+	subscriptions = make(map[string][]int64) // resource UUID -> chat IDs
+)
+
+func loadSubscriptions() error {
+	subsMu.Lock()
+	defer subsMu.Unlock()
+
+	subscriptions = make(map[string][]int64)
+	return loadBucket(bucketSubscriptions, func(uuid string, chats []int64) {
+		subscriptions[uuid] = chats
+	})
+}
+
+// Subscribe adds chatID to the alert recipients of a resource. It reports false if already subscribed.
+func Subscribe(uuid string, chatID int64) (bool, error) {
+	subsMu.Lock()
+	defer subsMu.Unlock()
+
+	for _, id := range subscriptions[uuid] {
+		if id == chatID {
+			return false, nil
+		}
+	}
+	chats := append(subscriptions[uuid], chatID)
+	if err := Store.Put(bucketSubscriptions, uuid, chats); err != nil {
+		return false, err
+	}
+	subscriptions[uuid] = chats
+	return true, nil
+}
+
+// Unsubscribe removes chatID from a resource's recipients. It reports false if it was not subscribed.
+func Unsubscribe(uuid string, chatID int64) (bool, error) {
+	subsMu.Lock()
+	defer subsMu.Unlock()
+
+	chats := subscriptions[uuid]
+	for i, id := range chats {
+		if id != chatID {
+			continue
+		}
+		kept := append(append([]int64(nil), chats[:i]...), chats[i+1:]...)
+		if len(kept) == 0 {
+			if err := Store.Delete(bucketSubscriptions, uuid); err != nil {
+				return false, err
+			}
+			delete(subscriptions, uuid)
+			return true, nil
+		}
+		if err := Store.Put(bucketSubscriptions, uuid, kept); err != nil {
+			return false, err
+		}
+		subscriptions[uuid] = kept
+		return true, nil
+	}
+	return false, nil
+}
+
+// Subscribers returns the chats subscribed to a resource.
+func Subscribers(uuid string) []int64 {
+	subsMu.RLock()
+	defer subsMu.RUnlock()
+	return append([]int64(nil), subscriptions[uuid]...)
+}
+
+// ChatSubscriptions returns the resource UUIDs chatID is subscribed to.
+func ChatSubscriptions(chatID int64) []string {
+	subsMu.RLock()
+	defer subsMu.RUnlock()
+
+	var out []string
+	for uuid, chats := range subscriptions {
+		for _, id := range chats {
+			if id == chatID {
+				out = append(out, uuid)
+				break
+			}
+		}
+	}
+	return out
+}
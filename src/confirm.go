@@ -0,0 +1,329 @@
+package src
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"strings"
+	"sync"
+	"time"
+
+	"coolifymanager/src/config"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+)
+
+const (
+	confirmTTL   = 60 * time.Second
+	typedNameTTL = 2 * time.Minute
+)
+
+// actionResult is what a confirmed action reports back. When deploymentUUID is
+// set, the result message keeps updating with that deployment's progress, and
+// rows stay on it next to the progress buttons.
+type actionResult struct {
+	text           string
+	appUUID        string
+	deploymentUUID string
+	rows           [][]gotgbot.InlineKeyboardButton
+}
+
+// destructiveAction describes a callback that must be confirmed before it runs.
+type destructiveAction struct {
+	// Verb is shown on the prompt and the confirm button, e.g. "Delete".
+	Verb string
+	// RequireName makes the user reply with the exact resource name after confirming.
+	RequireName bool
+	// Lookup returns the resource name shown in the prompt.
+	Lookup func(reqCtx context.Context, uuid string) (string, error)
+	// Execute performs the action and describes the outcome.
+	Execute func(reqCtx context.Context, b *gotgbot.Bot, uuid string) (actionResult, error)
+	// Back returns the callback data used by the cancel button.
+	Back func(uuid string) string
+	// Done optionally returns buttons shown after the action succeeds.
+	Done func(uuid string) gotgbot.InlineKeyboardMarkup
+}
+
+var destructiveActions = map[string]destructiveAction{
+	"stop": {
+		Verb:   "Stop",
+		Lookup: applicationName,
+		Execute: func(reqCtx context.Context, _ *gotgbot.Bot, uuid string) (actionResult, error) {
+			res, err := config.Coolify.StopApplicationByUUIDWithContext(reqCtx, uuid)
+			if err != nil {
+				return actionResult{}, err
+			}
+			return actionResult{text: "🛑 " + html.EscapeString(res.Message)}, nil
+		},
+		Back: func(uuid string) string { return "project_menu:" + uuid },
+	},
+	"delete": {
+		Verb:        "Delete",
+		RequireName: true,
+		Lookup:      applicationName,
+		Execute: func(reqCtx context.Context, _ *gotgbot.Bot, uuid string) (actionResult, error) {
+			if err := config.Coolify.DeleteApplicationByUUIDWithContext(reqCtx, uuid); err != nil {
+				return actionResult{}, err
+			}
+			return actionResult{text: "✅ Application deleted successfully."}, nil
+		},
+		Back: func(uuid string) string { return "project_menu:" + uuid },
+	},
+	// env_delete targets "<app uuid>:<env uuid>".
+	"env_delete": {
+		Verb: "Delete variable",
+		Lookup: func(reqCtx context.Context, id string) (string, error) {
+			appUUID, envUUID, _ := strings.Cut(id, ":")
+			env, err := findEnv(reqCtx, appUUID, envUUID)
+			if err != nil {
+				return "", err
+			}
+			return env.Key, nil
+		},
+		Execute: func(reqCtx context.Context, _ *gotgbot.Bot, id string) (actionResult, error) {
+			appUUID, envUUID, _ := strings.Cut(id, ":")
+			if err := config.Coolify.DeleteApplicationEnvWithContext(reqCtx, appUUID, envUUID); err != nil {
+				return actionResult{}, err
+			}
+			return actionResult{text: "✅ Variable deleted. Redeploy for the change to take effect."}, nil
+		},
+		Back: func(id string) string { return "env_menu:" + id },
+		Done: func(id string) gotgbot.InlineKeyboardMarkup {
+			appUUID, _, _ := strings.Cut(id, ":")
+			return envChangedMarkup(appUUID)
+		},
+	},
+	// rollback targets "<app uuid>:<deployment uuid>".
+	"rollback": {
+		Verb:    "Redeploy",
+		Lookup:  lookupRollback,
+		Execute: executeRollback,
+		Back: func(id string) string {
+			appUUID, _, _ := strings.Cut(id, ":")
+			return fmt.Sprintf("app_deployments:%s:1", appUUID)
+		},
+		Done: rollbackDoneMarkup,
+	},
+	"db_stop": {
+		Verb:   "Stop database",
+		Lookup: databaseName,
+		Execute: func(reqCtx context.Context, _ *gotgbot.Bot, uuid string) (actionResult, error) {
+			res, err := config.Coolify.StopDatabaseWithContext(reqCtx, uuid)
+			if err != nil {
+				return actionResult{}, err
+			}
+			return actionResult{text: "🛑 " + html.EscapeString(res.Message)}, nil
+		},
+		Back: func(uuid string) string { return "db_menu:" + uuid },
+	},
+	"db_delete": {
+		Verb:        "Delete database",
+		RequireName: true,
+		Lookup:      databaseName,
+		Execute: func(reqCtx context.Context, _ *gotgbot.Bot, uuid string) (actionResult, error) {
+			if err := config.Coolify.DeleteDatabaseByUUIDWithContext(reqCtx, uuid); err != nil {
+				return actionResult{}, err
+			}
+			return actionResult{text: "✅ Database deleted successfully."}, nil
+		},
+		Back: func(uuid string) string { return "db_menu:" + uuid },
+		Done: func(string) gotgbot.InlineKeyboardMarkup { return databaseListMarkup() },
+	},
+	"service_stop": {
+		Verb:    "Stop service",
+		Lookup:  serviceName,
+		Execute: executeServiceStop,
+		Back:    func(uuid string) string { return "service_menu:" + uuid },
+	},
+}
+
+// run executes the confirmed action, records it in the audit log and writes the
+// outcome into the given message.
+func (a destructiveAction) run(reqCtx context.Context, b *gotgbot.Bot, user *gotgbot.User, p pendingConfirmation, chatID, messageID int64) string {
+	uuid := p.uuid
+	res, err := a.Execute(reqCtx, b, uuid)
+	recordAudit(b, user, p.action, uuid, p.name, res.text, err)
+	text := res.text
+	opts := &gotgbot.EditMessageTextOpts{ChatId: chatID, MessageId: messageID, ParseMode: "HTML"}
+	if err != nil {
+		text = fmt.Sprintf("❌ %s failed: %s", a.Verb, html.EscapeString(errorText(err)))
+	} else if a.Done != nil {
+		opts.ReplyMarkup = a.Done(uuid)
+	}
+
+	_, _, _ = b.EditMessageText(text, opts)
+	if err == nil && res.deploymentUUID != "" {
+		trackDeployment(b, chatID, messageID, res.appUUID, res.deploymentUUID, a.Verb, res.rows...)
+	}
+	return text
+}
+
+type pendingConfirmation struct {
+	action    string
+	uuid      string
+	name      string
+	userID    int64
+	expiresAt time.Time
+}
+
+var (
+	confirmMu     sync.Mutex
+	confirmations = make(map[string]pendingConfirmation)
+)
+
+func applicationName(reqCtx context.Context, uuid string) (string, error) {
+	app, err := config.Coolify.GetApplicationByUUIDWithContext(reqCtx, uuid)
+	if err != nil {
+		return "", err
+	}
+	return app.Name, nil
+}
+
+func newNonce() string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(buf)
+}
+
+func storeConfirmation(p pendingConfirmation) string {
+	confirmMu.Lock()
+	defer confirmMu.Unlock()
+
+	now := time.Now()
+	for nonce, c := range confirmations {
+		if now.After(c.expiresAt) {
+			delete(confirmations, nonce)
+		}
+	}
+
+	nonce := newNonce()
+	confirmations[nonce] = p
+	return nonce
+}
+
+// takeConfirmation consumes the nonce if it belongs to userID and has not expired.
+func takeConfirmation(nonce string, userID int64) (pendingConfirmation, bool) {
+	confirmMu.Lock()
+	defer confirmMu.Unlock()
+
+	p, ok := confirmations[nonce]
+	if !ok || p.userID != userID {
+		return pendingConfirmation{}, false
+	}
+	delete(confirmations, nonce)
+	if time.Now().After(p.expiresAt) {
+		return pendingConfirmation{}, false
+	}
+	return p, true
+}
+
+// confirmationPrompt registers a nonce for action on uuid and returns the prompt to show userID.
+func confirmationPrompt(reqCtx context.Context, action, uuid string, userID int64) (string, gotgbot.InlineKeyboardMarkup, error) {
+	spec, ok := destructiveActions[action]
+	if !ok {
+		return "", gotgbot.InlineKeyboardMarkup{}, fmt.Errorf("unknown destructive action %q", action)
+	}
+
+	name, err := spec.Lookup(reqCtx, uuid)
+	if err != nil {
+		return "", gotgbot.InlineKeyboardMarkup{}, err
+	}
+
+	nonce := storeConfirmation(pendingConfirmation{
+		action:    action,
+		uuid:      uuid,
+		name:      name,
+		userID:    userID,
+		expiresAt: time.Now().Add(confirmTTL),
+	})
+
+	text := fmt.Sprintf(
+		"⚠️ <b>%s %s?</b>\nUUID: <code>%s</code>\n\nThis request expires in %d seconds.",
+		spec.Verb, html.EscapeString(name), uuid, int(confirmTTL.Seconds()),
+	)
+	if spec.RequireName {
+		text += "\nYou will be asked to type the name to finish."
+	}
+
+	btns := [][]gotgbot.InlineKeyboardButton{
+		{{Text: "✅ Yes, " + strings.ToLower(spec.Verb), CallbackData: "confirm:" + nonce}},
+		{{Text: "✖ Cancel", CallbackData: spec.Back(uuid)}},
+	}
+	return text, gotgbot.InlineKeyboardMarkup{InlineKeyboard: btns}, nil
+}
+
+// askConfirmation replaces the callback message with a confirm/cancel prompt for action.
+func askConfirmation(b *gotgbot.Bot, ctx *ext.Context, action, uuid string) error {
+	cb := ctx.CallbackQuery
+	text, markup, err := confirmationPrompt(updateContext(ctx), action, uuid, ctx.EffectiveUser.Id)
+	if err != nil {
+		_, _, err = cb.Message.EditText(b, "❌ Failed to load resource: "+errorText(err), nil)
+		return err
+	}
+
+	_, _, err = cb.Message.EditText(b, text, &gotgbot.EditMessageTextOpts{
+		ParseMode:   "HTML",
+		ReplyMarkup: markup,
+	})
+	return err
+}
+
+func confirmHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	cb := ctx.CallbackQuery
+	if !ensureAllowed(b, ctx) {
+		return nil
+	}
+
+	pending, ok := takeConfirmation(strings.TrimPrefix(cb.Data, "confirm:"), ctx.EffectiveUser.Id)
+	if !ok {
+		_, _ = cb.Answer(b, &gotgbot.AnswerCallbackQueryOpts{
+			Text:      "⌛ This confirmation has expired. Please start again.",
+			ShowAlert: true,
+		})
+		return nil
+	}
+	resourceUUID, _, _ := strings.Cut(pending.uuid, ":")
+	if !isAllowed(updateContext(ctx), pending.userID, pending.action, resourceUUID) {
+		_, _ = cb.Answer(b, &gotgbot.AnswerCallbackQueryOpts{
+			Text:      "🚫 You are not authorized.",
+			ShowAlert: true,
+		})
+		return nil
+	}
+	_, _ = cb.Answer(b, nil)
+
+	spec := destructiveActions[pending.action]
+	chatID := cb.Message.GetChat().Id
+	messageID := cb.Message.GetMessageId()
+
+	if !spec.RequireName {
+		spec.run(updateContext(ctx), b, ctx.EffectiveUser, pending, chatID, messageID)
+		return nil
+	}
+
+	prompt := fmt.Sprintf(
+		"✍️ To %s, reply with the exact name:\n<code>%s</code>\n\nAny other reply cancels. Expires in %d seconds.",
+		strings.ToLower(spec.Verb), html.EscapeString(pending.name), int(typedNameTTL.Seconds()),
+	)
+	if _, _, err := cb.Message.EditText(b, prompt, &gotgbot.EditMessageTextOpts{ParseMode: "HTML"}); err != nil {
+		return err
+	}
+
+	awaitInput(chatID, pending.userID, typedNameTTL, func(b *gotgbot.Bot, ctx *ext.Context) error {
+		msg := ctx.EffectiveMessage
+		if strings.TrimSpace(msg.Text) != pending.name {
+			_, err := msg.Reply(b, "❌ Name does not match. "+spec.Verb+" cancelled.", nil)
+			return err
+		}
+
+		text := spec.run(updateContext(ctx), b, ctx.EffectiveUser, pending, chatID, messageID)
+		_, err := msg.Reply(b, text, &gotgbot.SendMessageOpts{ParseMode: "HTML"})
+		return err
+	})
+	return nil
+}
@@ -2,6 +2,7 @@ package coolify
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -9,13 +10,13 @@ import (
 	"strings"
 )
 
-func uploadToBatbin(content string) (string, error) {
+func uploadToBatbin(ctx context.Context, content string) (string, error) {
 	content = strings.TrimSpace(content)
 	if content == "" {
 		return "", fmt.Errorf("content cannot be empty")
 	}
 
-	req, err := http.NewRequest("POST", "https://batbin.me/api/v2/paste", bytes.NewBufferString(content))
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://batbin.me/api/v2/paste", bytes.NewBufferString(content))
 	if err != nil {
 		return "", fmt.Errorf("error creating request: %v", err)
 	}
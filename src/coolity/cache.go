@@ -1,27 +1,65 @@
 package coolify
 
 import (
+	"container/list"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 )
 
+const (
+	defaultCacheEntries = 1000
+	janitorInterval     = time.Minute
+)
+
 type cacheEntry struct {
+	key       string
 	value     any
 	expiresAt time.Time
 }
 
-// MemoryCache is a minimal in-memory cache with TTL-based invalidation.
+// CacheStats is a snapshot of a MemoryCache's size and counters.
+type CacheStats struct {
+	Entries    int
+	MaxEntries int
+	Hits       uint64
+	Misses     uint64
+	// Evictions counts entries dropped to stay within MaxEntries.
+	Evictions uint64
+	// Expired counts entries removed after their TTL, by Get or the janitor.
+	Expired uint64
+}
+
+// CacheGroup is the number of entries sharing a key prefix such as "apps:".
+type CacheGroup struct {
+	Prefix  string
+	Entries int
+}
+
+// MemoryCache is an in-memory LRU cache with per-entry TTLs. Once used, a
+// janitor goroutine removes expired entries every minute until Close.
 type MemoryCache struct {
-	ttl  time.Duration
-	mu   sync.RWMutex
-	data map[string]cacheEntry
+	ttl        time.Duration
+	maxEntries int
+
+	mu    sync.Mutex
+	data  map[string]*list.Element
+	order *list.List // front is most recently used
+
+	stats     CacheStats
+	janitor   sync.Once
+	stop      chan struct{}
+	closeOnce sync.Once
 }
 
 func NewMemoryCache() *MemoryCache {
 	return &MemoryCache{
-		ttl: 30 * time.Second,
-		data: make(map[string]cacheEntry),
+		ttl:        30 * time.Second,
+		maxEntries: defaultCacheEntries,
+		data:       make(map[string]*list.Element),
+		order:      list.New(),
+		stop:       make(chan struct{}),
 	}
 }
 
@@ -30,43 +68,150 @@ func (c *MemoryCache) WithTTL(ttl time.Duration) *MemoryCache {
 	return c
 }
 
+// WithMaxEntries bounds the cache; the least recently used entries are evicted
+// beyond n. n <= 0 keeps the default.
+func (c *MemoryCache) WithMaxEntries(n int) *MemoryCache {
+	if n > 0 {
+		c.maxEntries = n
+	}
+	return c
+}
+
 func (c *MemoryCache) Get(key string) (any, bool) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
-	entry, ok := c.data[key]
-	if !ok || time.Now().After(entry.expiresAt) {
+	el, ok := c.data[key]
+	if !ok {
+		c.stats.Misses++
+		return nil, false
+	}
+	entry := el.Value.(*cacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.remove(el)
+		c.stats.Expired++
+		c.stats.Misses++
 		return nil, false
 	}
+
+	c.order.MoveToFront(el)
+	c.stats.Hits++
 	return entry.value, true
 }
 
 func (c *MemoryCache) Set(key string, value any, ttl time.Duration) {
+	c.janitor.Do(func() { go c.runJanitor() })
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
 	if ttl <= 0 {
 		ttl = c.ttl
 	}
-	c.data[key] = cacheEntry{
-		value:     value,
-		expiresAt: time.Now().Add(ttl),
+	expiresAt := time.Now().Add(ttl)
+	if el, ok := c.data[key]; ok {
+		entry := el.Value.(*cacheEntry)
+		entry.value, entry.expiresAt = value, expiresAt
+		c.order.MoveToFront(el)
+		return
+	}
+
+	c.data[key] = c.order.PushFront(&cacheEntry{key: key, value: value, expiresAt: expiresAt})
+	for c.order.Len() > c.maxEntries {
+		c.remove(c.order.Back())
+		c.stats.Evictions++
 	}
 }
 
 func (c *MemoryCache) Delete(key string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	delete(c.data, key)
+
+	if el, ok := c.data[key]; ok {
+		c.remove(el)
+	}
 }
 
-func (c *MemoryCache) DeletePrefix(prefix string) {
+// DeletePrefix removes every key starting with prefix and returns how many were removed.
+func (c *MemoryCache) DeletePrefix(prefix string) int {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	for k := range c.data {
+	removed := 0
+	for k, el := range c.data {
 		if strings.HasPrefix(k, prefix) {
-			delete(c.data, k)
+			c.remove(el)
+			removed++
+		}
+	}
+	return removed
+}
+
+func (c *MemoryCache) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Entries = c.order.Len()
+	stats.MaxEntries = c.maxEntries
+	return stats
+}
+
+// Groups counts entries by the key segment before the first ':' ("apps",
+// "deployments", ...), sorted by name.
+func (c *MemoryCache) Groups() []CacheGroup {
+	c.mu.Lock()
+	counts := make(map[string]int)
+	for k := range c.data {
+		group, _, _ := strings.Cut(k, ":")
+		counts[group]++
+	}
+	c.mu.Unlock()
+
+	groups := make([]CacheGroup, 0, len(counts))
+	for name, n := range counts {
+		groups = append(groups, CacheGroup{Prefix: name + ":", Entries: n})
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Prefix < groups[j].Prefix })
+	return groups
+}
+
+// remove must be called with mu held.
+func (c *MemoryCache) remove(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.data, el.Value.(*cacheEntry).key)
+}
+
+// Close stops the janitor. The cache stays usable, but expired entries are
+// then only removed when looked up.
+func (c *MemoryCache) Close() {
+	c.closeOnce.Do(func() { close(c.stop) })
+}
+
+func (c *MemoryCache) runJanitor() {
+	ticker := time.NewTicker(janitorInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+			c.removeExpired()
+		}
+	}
+}
+
+func (c *MemoryCache) removeExpired() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for el := c.order.Back(); el != nil; {
+		prev := el.Prev()
+		if now.After(el.Value.(*cacheEntry).expiresAt) {
+			c.remove(el)
+			c.stats.Expired++
 		}
+		el = prev
 	}
 }
@@ -0,0 +1,125 @@
+package coolify
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMemoryCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewMemoryCache().WithMaxEntries(3)
+	defer c.Close()
+
+	c.Set("a", 1, time.Minute)
+	c.Set("b", 2, time.Minute)
+	c.Set("c", 3, time.Minute)
+	c.Get("a")                  // a is now the most recently used
+	c.Set("b", 20, time.Minute) // so is b, leaving c the oldest
+	c.Set("d", 4, time.Minute)
+	c.Set("e", 5, time.Minute)
+
+	for key, want := range map[string]bool{"a": false, "b": true, "c": false, "d": true, "e": true} {
+		if _, ok := c.Get(key); ok != want {
+			t.Errorf("Get(%q) present = %v, want %v", key, ok, want)
+		}
+	}
+	if v, _ := c.Get("b"); v != 20 {
+		t.Errorf("Get(b) = %v, want the updated value 20", v)
+	}
+	if stats := c.Stats(); stats.Entries != 3 || stats.Evictions != 2 {
+		t.Errorf("stats = %+v, want 3 entries and 2 evictions", stats)
+	}
+}
+
+func TestMemoryCacheExpiry(t *testing.T) {
+	c := NewMemoryCache().WithTTL(time.Hour)
+	defer c.Close()
+
+	c.Set("short", 1, time.Millisecond)
+	c.Set("default", 2, 0)
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := c.Get("short"); ok {
+		t.Error("Get(short) returned an expired entry")
+	}
+	if v, ok := c.Get("default"); !ok || v != 2 {
+		t.Errorf("Get(default) = %v, %v; want the cache TTL to apply", v, ok)
+	}
+	if stats := c.Stats(); stats.Expired != 1 || stats.Entries != 1 {
+		t.Errorf("stats = %+v, want 1 expired and 1 entry", stats)
+	}
+}
+
+func TestMemoryCacheRemoveExpired(t *testing.T) {
+	c := NewMemoryCache()
+	defer c.Close()
+
+	c.Set("a", 1, time.Millisecond)
+	c.Set("b", 2, time.Millisecond)
+	c.Set("c", 3, time.Minute)
+	time.Sleep(5 * time.Millisecond)
+	c.removeExpired()
+
+	if stats := c.Stats(); stats.Entries != 1 || stats.Expired != 2 {
+		t.Errorf("stats = %+v, want 1 entry and 2 expired", stats)
+	}
+}
+
+func TestMemoryCacheCounters(t *testing.T) {
+	c := NewMemoryCache().WithMaxEntries(10)
+	defer c.Close()
+
+	c.Set("a", 1, time.Minute)
+	c.Get("a")
+	c.Get("a")
+	c.Get("missing")
+
+	want := CacheStats{Entries: 1, MaxEntries: 10, Hits: 2, Misses: 1}
+	if got := c.Stats(); got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+}
+
+func TestMemoryCacheDeletePrefix(t *testing.T) {
+	c := NewMemoryCache()
+	defer c.Close()
+
+	for _, key := range []string{"apps:list", "apps:detail:1", "deployments:list", "servers:1"} {
+		c.Set(key, key, time.Minute)
+	}
+
+	if n := c.DeletePrefix("apps:"); n != 2 {
+		t.Errorf("DeletePrefix(apps:) = %d, want 2", n)
+	}
+	if n := c.DeletePrefix("apps:"); n != 0 {
+		t.Errorf("second DeletePrefix(apps:) = %d, want 0", n)
+	}
+	want := []CacheGroup{{Prefix: "deployments:", Entries: 1}, {Prefix: "servers:", Entries: 1}}
+	if got := c.Groups(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Groups() = %+v, want %+v", got, want)
+	}
+
+	if n := c.DeletePrefix(""); n != 2 {
+		t.Errorf("DeletePrefix(\"\") = %d, want 2", n)
+	}
+	if stats := c.Stats(); stats.Entries != 0 {
+		t.Errorf("entries after flushing everything = %d", stats.Entries)
+	}
+}
+
+func TestMemoryCacheCloseStopsJanitor(t *testing.T) {
+	c := NewMemoryCache()
+	done := make(chan struct{})
+	go func() {
+		c.runJanitor()
+		close(done)
+	}()
+
+	c.Close()
+	c.Close() // closing twice is harmless
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("janitor still running after Close")
+	}
+}
@@ -1,8 +1,9 @@
 package coolify
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -10,6 +11,8 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -18,17 +21,13 @@ const (
 	defaultCacheTTL   = 30 * time.Second
 )
 
-type notFoundError struct {
-	message string
-}
-
-func (e notFoundError) Error() string {
-	return e.message
-}
-
+// Client talks to the Coolify API. Every exported method has a WithContext
+// variant that takes a context.Context; the plain method runs under the
+// client's base context (see WithBaseContext).
 type Client struct {
-	BaseURL    string
-	Token      string
+	BaseURL string
+	Token   string
+	// APIVersion is tried first when probing and used until a probe succeeds.
 	APIVersion string
 	Client     *http.Client
 
@@ -38,6 +37,14 @@ type Client struct {
 	cacheTTL time.Duration
 
 	fallbackVersions []string
+	version          atomic.Pointer[VersionInfo]
+	probeMu          sync.Mutex // guards lastProbe and probing
+	lastProbe        time.Time
+	probing          bool
+
+	baseCtx        context.Context
+	requestTimeout time.Duration
+	retry          RetryPolicy
 }
 
 type ClientOption func(*Client)
@@ -51,6 +58,7 @@ func NewClient(baseURL, token string, opts ...ClientOption) *Client {
 		cache:      NewMemoryCache(),
 		cacheTTL:   defaultCacheTTL,
 		fallbackVersions: []string{"v4", "v3", "v2", "v1"},
+		retry:            DefaultRetryPolicy,
 	}
 
 	for _, opt := range opts {
@@ -104,6 +112,33 @@ func WithDebug(enabled bool) ClientOption {
 	}
 }
 
+// WithBaseContext sets the context used by the methods that don't take one.
+// Cancelling it aborts their in-flight requests, e.g. on shutdown.
+func WithBaseContext(ctx context.Context) ClientOption {
+	return func(c *Client) {
+		if ctx != nil {
+			c.baseCtx = ctx
+		}
+	}
+}
+
+// WithRequestTimeout bounds every call, including its version fallbacks, when
+// the caller's context has no earlier deadline.
+func WithRequestTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		if timeout > 0 {
+			c.requestTimeout = timeout
+		}
+	}
+}
+
+func (c *Client) baseContext() context.Context {
+	if c.baseCtx == nil {
+		return context.Background()
+	}
+	return c.baseCtx
+}
+
 func (c *Client) apiURL(path string, query url.Values) string {
 	base := strings.TrimSuffix(c.BaseURL, "/")
 	if !strings.HasPrefix(path, "/") {
@@ -142,28 +177,18 @@ func (c *Client) do(req *http.Request) ([]byte, error) {
 	if c.Debug {
 		log.Printf("[coolify] %s %s", req.Method, req.URL.String())
 	}
-	resp, err := c.Client.Do(req)
+	resp, err := c.send(req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode == http.StatusUnauthorized {
-		return nil, errors.New("unauthenticated: invalid or missing token (401)")
-	}
-	if resp.StatusCode == http.StatusBadRequest {
-		return nil, errors.New("invalid token (400)")
-	}
-	if resp.StatusCode == http.StatusNotFound {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, notFoundError{message: fmt.Sprintf("resource not found (404): %s", strings.TrimSpace(string(body)))}
-	}
 	if resp.StatusCode >= 300 {
 		body, _ := io.ReadAll(resp.Body)
 		if c.Debug {
 			log.Printf("[coolify] %s %s -> %s\n%s", req.Method, req.URL.String(), resp.Status, strings.TrimSpace(string(body)))
 		}
-		return nil, fmt.Errorf("unexpected response: %s (%s)", resp.Status, strings.TrimSpace(string(body)))
+		return nil, newAPIError(req, resp, body)
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -197,36 +222,41 @@ func (c *Client) versionsToTry() []string {
 	return list
 }
 
-func (c *Client) doWithFallback(method, path string, query url.Values, body io.Reader) ([]byte, error) {
-	versions := c.versionsToTry()
-	var lastErr error
-
-	for idx, version := range versions {
-		url := c.apiURLWithVersion(version, path, query)
-		req, err := http.NewRequest(method, url, body)
-		if err != nil {
-			return nil, err
-		}
+// doAPI sends one request to the negotiated API version. The body is a byte
+// slice so every attempt, including retries, sends it in full.
+func (c *Client) doAPI(ctx context.Context, method, path string, query url.Values, body []byte) ([]byte, error) {
+	ctx, cancel := c.withRequestTimeout(ctx)
+	defer cancel()
 
-		respBody, err := c.do(req)
-		if err == nil {
-			// Cache the working version to avoid future fallbacks.
-			c.APIVersion = version
-			return respBody, nil
-		}
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.apiURLWithVersion(c.negotiatedVersion(ctx), path, query), reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
 
-		lastErr = err
-		_, isNotFound := err.(notFoundError)
-			if !isNotFound || idx == len(versions)-1 {
-				return nil, err
-			}
+	return c.do(req)
+}
 
-		if c.Debug {
-			log.Printf("[coolify] received 404 with version %s, trying next version", version)
-		}
+func (c *Client) withRequestTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.requestTimeout <= 0 {
+		return ctx, func() {}
 	}
+	return context.WithTimeout(ctx, c.requestTimeout)
+}
 
-	return nil, lastErr
+func (c *Client) doJSON(ctx context.Context, method, path string, payload any) ([]byte, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return c.doAPI(ctx, method, path, nil, data)
 }
 
 func decodePage[T any](body []byte) (*Page[T], error) {
@@ -246,6 +276,11 @@ func decodePage[T any](body []byte) (*Page[T], error) {
 	return &page, nil
 }
 
+// Cache returns the client's response cache.
+func (c *Client) Cache() *MemoryCache {
+	return c.cache
+}
+
 func (c *Client) cacheResult(key string, value any) {
 	if c.cache == nil {
 		return
@@ -270,14 +305,21 @@ func (c *Client) invalidateApplications(uuid string) {
 	}
 }
 
-func listPage[T any](client *Client, path string, query url.Values, cacheKey string) (*Page[T], error) {
+func (c *Client) invalidateDeployments() {
+	if c.cache == nil {
+		return
+	}
+	c.cache.DeletePrefix("deployments:")
+}
+
+func listPage[T any](ctx context.Context, client *Client, path string, query url.Values, cacheKey string) (*Page[T], error) {
 	if v, ok := client.getCached(cacheKey); ok {
 		if res, ok := v.(*Page[T]); ok {
 			return res, nil
 		}
 	}
 
-	body, err := client.doWithFallback(http.MethodGet, path, query, nil)
+	body, err := client.doAPI(ctx, http.MethodGet, path, query, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -300,6 +342,10 @@ func listPage[T any](client *Client, path string, query url.Values, cacheKey str
 }
 
 func (c *Client) ListApplications(page, perPage int) (*Page[Application], error) {
+	return c.ListApplicationsWithContext(c.baseContext(), page, perPage)
+}
+
+func (c *Client) ListApplicationsWithContext(ctx context.Context, page, perPage int) (*Page[Application], error) {
 	query := url.Values{}
 	if page > 0 {
 		query.Set("page", strconv.Itoa(page))
@@ -308,10 +354,14 @@ func (c *Client) ListApplications(page, perPage int) (*Page[Application], error)
 		query.Set("per_page", strconv.Itoa(perPage))
 	}
 	cacheKey := fmt.Sprintf("apps:list:%d:%d", page, perPage)
-	return listPage[Application](c, "/applications", query, cacheKey)
+	return listPage[Application](ctx, c, "/applications", query, cacheKey)
 }
 
 func (c *Client) GetApplicationByUUID(uuid string) (*ApplicationDetail, error) {
+	return c.GetApplicationByUUIDWithContext(c.baseContext(), uuid)
+}
+
+func (c *Client) GetApplicationByUUIDWithContext(ctx context.Context, uuid string) (*ApplicationDetail, error) {
 	cacheKey := "apps:detail:" + uuid
 	if cached, ok := c.getCached(cacheKey); ok {
 		if app, ok := cached.(*ApplicationDetail); ok {
@@ -319,7 +369,7 @@ func (c *Client) GetApplicationByUUID(uuid string) (*ApplicationDetail, error) {
 		}
 	}
 
-	body, err := c.doWithFallback(http.MethodGet, "/applications/"+uuid, nil, nil)
+	body, err := c.doAPI(ctx, http.MethodGet, "/applications/"+uuid, nil, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -333,8 +383,25 @@ func (c *Client) GetApplicationByUUID(uuid string) (*ApplicationDetail, error) {
 	return &app, nil
 }
 
+func (c *Client) UpdateApplication(uuid string, patch ApplicationPatch) error {
+	return c.UpdateApplicationWithContext(c.baseContext(), uuid, patch)
+}
+
+func (c *Client) UpdateApplicationWithContext(ctx context.Context, uuid string, patch ApplicationPatch) error {
+	if _, err := c.doJSON(ctx, http.MethodPatch, "/applications/"+uuid, patch); err != nil {
+		return err
+	}
+
+	c.invalidateApplications(uuid)
+	return nil
+}
+
 func (c *Client) DeleteApplicationByUUID(uuid string) error {
-	if _, err := c.doWithFallback(http.MethodDelete, "/applications/"+uuid, nil, nil); err != nil {
+	return c.DeleteApplicationByUUIDWithContext(c.baseContext(), uuid)
+}
+
+func (c *Client) DeleteApplicationByUUIDWithContext(ctx context.Context, uuid string) error {
+	if _, err := c.doAPI(ctx, http.MethodDelete, "/applications/"+uuid, nil, nil); err != nil {
 		return err
 	}
 
@@ -342,8 +409,17 @@ func (c *Client) DeleteApplicationByUUID(uuid string) error {
 	return nil
 }
 
-func (c *Client) GetApplicationLogsByUUID(uuid string) (string, error) {
-	body, err := c.doWithFallback(http.MethodGet, "/applications/"+uuid+"/logs", url.Values{"lines": []string{"-1"}}, nil)
+// GetApplicationLogsByUUID uploads the application's logs and returns the paste URL.
+// lines <= 0 fetches the full log.
+func (c *Client) GetApplicationLogsByUUID(uuid string, lines int) (string, error) {
+	return c.GetApplicationLogsByUUIDWithContext(c.baseContext(), uuid, lines)
+}
+
+func (c *Client) GetApplicationLogsByUUIDWithContext(ctx context.Context, uuid string, lines int) (string, error) {
+	if lines <= 0 {
+		lines = -1
+	}
+	body, err := c.doAPI(ctx, http.MethodGet, "/applications/"+uuid+"/logs", url.Values{"lines": []string{strconv.Itoa(lines)}}, nil)
 	if err != nil {
 		return "", err
 	}
@@ -353,11 +429,15 @@ func (c *Client) GetApplicationLogsByUUID(uuid string) (string, error) {
 		return "", err
 	}
 
-	return uploadToBatbin(result.Logs)
+	return uploadToBatbin(ctx, result.Logs)
 }
 
 func (c *Client) GetApplicationEnvsByUUID(uuid string) ([]EnvironmentVariable, error) {
-	body, err := c.doWithFallback(http.MethodGet, "/applications/"+uuid+"/envs", nil, nil)
+	return c.GetApplicationEnvsByUUIDWithContext(c.baseContext(), uuid)
+}
+
+func (c *Client) GetApplicationEnvsByUUIDWithContext(ctx context.Context, uuid string) ([]EnvironmentVariable, error) {
+	body, err := c.doAPI(ctx, http.MethodGet, "/applications/"+uuid+"/envs", nil, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -370,7 +450,57 @@ func (c *Client) GetApplicationEnvsByUUID(uuid string) ([]EnvironmentVariable, e
 	return envs, nil
 }
 
+func (c *Client) CreateApplicationEnv(uuid string, env EnvironmentVariableInput) (*CreateResourceResponse, error) {
+	return c.CreateApplicationEnvWithContext(c.baseContext(), uuid, env)
+}
+
+func (c *Client) CreateApplicationEnvWithContext(ctx context.Context, uuid string, env EnvironmentVariableInput) (*CreateResourceResponse, error) {
+	body, err := c.doJSON(ctx, http.MethodPost, "/applications/"+uuid+"/envs", env)
+	if err != nil {
+		return nil, err
+	}
+
+	var result CreateResourceResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateApplicationEnv updates the variable matching env.Key.
+func (c *Client) UpdateApplicationEnv(uuid string, env EnvironmentVariableInput) error {
+	return c.UpdateApplicationEnvWithContext(c.baseContext(), uuid, env)
+}
+
+func (c *Client) UpdateApplicationEnvWithContext(ctx context.Context, uuid string, env EnvironmentVariableInput) error {
+	_, err := c.doJSON(ctx, http.MethodPatch, "/applications/"+uuid+"/envs", env)
+	return err
+}
+
+// BulkUpdateApplicationEnvs creates or updates every variable in envs in one request.
+func (c *Client) BulkUpdateApplicationEnvs(uuid string, envs []EnvironmentVariableInput) error {
+	return c.BulkUpdateApplicationEnvsWithContext(c.baseContext(), uuid, envs)
+}
+
+func (c *Client) BulkUpdateApplicationEnvsWithContext(ctx context.Context, uuid string, envs []EnvironmentVariableInput) error {
+	_, err := c.doJSON(ctx, http.MethodPatch, "/applications/"+uuid+"/envs/bulk", map[string]any{"data": envs})
+	return err
+}
+
+func (c *Client) DeleteApplicationEnv(uuid, envUUID string) error {
+	return c.DeleteApplicationEnvWithContext(c.baseContext(), uuid, envUUID)
+}
+
+func (c *Client) DeleteApplicationEnvWithContext(ctx context.Context, uuid, envUUID string) error {
+	_, err := c.doAPI(ctx, http.MethodDelete, "/applications/"+uuid+"/envs/"+envUUID, nil, nil)
+	return err
+}
+
 func (c *Client) StartApplicationDeployment(uuid string, force, instantDeploy bool) (*StartDeploymentResponse, error) {
+	return c.StartApplicationDeploymentWithContext(c.baseContext(), uuid, force, instantDeploy)
+}
+
+func (c *Client) StartApplicationDeploymentWithContext(ctx context.Context, uuid string, force, instantDeploy bool) (*StartDeploymentResponse, error) {
 	query := url.Values{}
 	if force {
 		query.Set("force", "true")
@@ -379,7 +509,7 @@ func (c *Client) StartApplicationDeployment(uuid string, force, instantDeploy bo
 		query.Set("instant_deploy", "true")
 	}
 
-	body, err := c.doWithFallback(http.MethodGet, "/applications/"+uuid+"/start", query, nil)
+	body, err := c.doAPI(mutating(ctx), http.MethodGet, "/applications/"+uuid+"/start", query, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -391,11 +521,16 @@ func (c *Client) StartApplicationDeployment(uuid string, force, instantDeploy bo
 
 	// Deployment kicks off a new state, so bust caches.
 	c.invalidateApplications(uuid)
+	c.invalidateDeployments()
 	return &result, nil
 }
 
 func (c *Client) StopApplicationByUUID(uuid string) (*StopApplicationResponse, error) {
-	body, err := c.doWithFallback(http.MethodGet, "/applications/"+uuid+"/stop", nil, nil)
+	return c.StopApplicationByUUIDWithContext(c.baseContext(), uuid)
+}
+
+func (c *Client) StopApplicationByUUIDWithContext(ctx context.Context, uuid string) (*StopApplicationResponse, error) {
+	body, err := c.doAPI(mutating(ctx), http.MethodGet, "/applications/"+uuid+"/stop", nil, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -410,7 +545,11 @@ func (c *Client) StopApplicationByUUID(uuid string) (*StopApplicationResponse, e
 }
 
 func (c *Client) RestartApplicationByUUID(uuid string) (*StartDeploymentResponse, error) {
-	body, err := c.doWithFallback(http.MethodGet, "/applications/"+uuid+"/restart", nil, nil)
+	return c.RestartApplicationByUUIDWithContext(c.baseContext(), uuid)
+}
+
+func (c *Client) RestartApplicationByUUIDWithContext(ctx context.Context, uuid string) (*StartDeploymentResponse, error) {
+	body, err := c.doAPI(mutating(ctx), http.MethodGet, "/applications/"+uuid+"/restart", nil, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -421,10 +560,15 @@ func (c *Client) RestartApplicationByUUID(uuid string) (*StartDeploymentResponse
 	}
 
 	c.invalidateApplications(uuid)
+	c.invalidateDeployments()
 	return &result, nil
 }
 
 func (c *Client) ListDeployments(page, perPage int) (*Page[Deployment], error) {
+	return c.ListDeploymentsWithContext(c.baseContext(), page, perPage)
+}
+
+func (c *Client) ListDeploymentsWithContext(ctx context.Context, page, perPage int) (*Page[Deployment], error) {
 	query := url.Values{}
 	if page > 0 {
 		query.Set("page", strconv.Itoa(page))
@@ -433,10 +577,14 @@ func (c *Client) ListDeployments(page, perPage int) (*Page[Deployment], error) {
 		query.Set("per_page", strconv.Itoa(perPage))
 	}
 	cacheKey := fmt.Sprintf("deployments:list:%d:%d", page, perPage)
-	return listPage[Deployment](c, "/deployments", query, cacheKey)
+	return listPage[Deployment](ctx, c, "/deployments", query, cacheKey)
 }
 
 func (c *Client) ListDeploymentsByApplication(uuid string, page, perPage int) (*Page[Deployment], error) {
+	return c.ListDeploymentsByApplicationWithContext(c.baseContext(), uuid, page, perPage)
+}
+
+func (c *Client) ListDeploymentsByApplicationWithContext(ctx context.Context, uuid string, page, perPage int) (*Page[Deployment], error) {
 	query := url.Values{}
 	if page > 0 {
 		query.Set("page", strconv.Itoa(page))
@@ -445,10 +593,53 @@ func (c *Client) ListDeploymentsByApplication(uuid string, page, perPage int) (*
 		query.Set("per_page", strconv.Itoa(perPage))
 	}
 	cacheKey := fmt.Sprintf("deployments:app:%s:%d:%d", uuid, page, perPage)
-	return listPage[Deployment](c, "/applications/"+uuid+"/deployments", query, cacheKey)
+	return listPage[Deployment](ctx, c, "/applications/"+uuid+"/deployments", query, cacheKey)
+}
+
+// GetDeploymentByUUID fetches the live state of a deployment. Results are never cached
+// because callers poll it while the deployment progresses.
+func (c *Client) GetDeploymentByUUID(uuid string) (*DeploymentDetail, error) {
+	return c.GetDeploymentByUUIDWithContext(c.baseContext(), uuid)
+}
+
+func (c *Client) GetDeploymentByUUIDWithContext(ctx context.Context, uuid string) (*DeploymentDetail, error) {
+	body, err := c.doAPI(ctx, http.MethodGet, "/deployments/"+uuid, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var deployment DeploymentDetail
+	if err := json.Unmarshal(body, &deployment); err != nil {
+		return nil, err
+	}
+	return &deployment, nil
+}
+
+// CancelDeployment stops a queued or running deployment.
+func (c *Client) CancelDeployment(uuid string) (*MessageResponse, error) {
+	return c.CancelDeploymentWithContext(c.baseContext(), uuid)
+}
+
+func (c *Client) CancelDeploymentWithContext(ctx context.Context, uuid string) (*MessageResponse, error) {
+	body, err := c.doAPI(ctx, http.MethodPost, "/deployments/"+uuid+"/cancel", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result MessageResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	c.invalidateDeployments()
+	return &result, nil
 }
 
 func (c *Client) ListEnvironments(page, perPage int) (*Page[Environment], error) {
+	return c.ListEnvironmentsWithContext(c.baseContext(), page, perPage)
+}
+
+func (c *Client) ListEnvironmentsWithContext(ctx context.Context, page, perPage int) (*Page[Environment], error) {
 	query := url.Values{}
 	if page > 0 {
 		query.Set("page", strconv.Itoa(page))
@@ -457,10 +648,14 @@ func (c *Client) ListEnvironments(page, perPage int) (*Page[Environment], error)
 		query.Set("per_page", strconv.Itoa(perPage))
 	}
 	cacheKey := fmt.Sprintf("environments:list:%d:%d", page, perPage)
-	return listPage[Environment](c, "/environments", query, cacheKey)
+	return listPage[Environment](ctx, c, "/environments", query, cacheKey)
 }
 
 func (c *Client) ListDatabases(page, perPage int) (*Page[Database], error) {
+	return c.ListDatabasesWithContext(c.baseContext(), page, perPage)
+}
+
+func (c *Client) ListDatabasesWithContext(ctx context.Context, page, perPage int) (*Page[Database], error) {
 	query := url.Values{}
 	if page > 0 {
 		query.Set("page", strconv.Itoa(page))
@@ -469,5 +664,5 @@ func (c *Client) ListDatabases(page, perPage int) (*Page[Database], error) {
 		query.Set("per_page", strconv.Itoa(perPage))
 	}
 	cacheKey := fmt.Sprintf("databases:list:%d:%d", page, perPage)
-	return listPage[Database](c, "/databases", query, cacheKey)
+	return listPage[Database](ctx, c, "/databases", query, cacheKey)
 }
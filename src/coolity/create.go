@@ -0,0 +1,146 @@
+package coolify
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+)
+
+// CreateApplicationRequest is the payload shared by the application create endpoints.
+// Each endpoint reads the fields relevant to its source and ignores the rest.
+type CreateApplicationRequest struct {
+	ProjectUUID     string `json:"project_uuid"`
+	ServerUUID      string `json:"server_uuid"`
+	EnvironmentName string `json:"environment_name,omitempty"`
+	EnvironmentUUID string `json:"environment_uuid,omitempty"`
+	Name            string `json:"name,omitempty"`
+	Description     string `json:"description,omitempty"`
+
+	GitRepository  string `json:"git_repository,omitempty"`
+	GitBranch      string `json:"git_branch,omitempty"`
+	BuildPack      string `json:"build_pack,omitempty"`
+	GitHubAppUUID  string `json:"github_app_uuid,omitempty"`
+	PrivateKeyUUID string `json:"private_key_uuid,omitempty"`
+
+	Dockerfile              string `json:"dockerfile,omitempty"`
+	DockerRegistryImageName string `json:"docker_registry_image_name,omitempty"`
+	DockerRegistryImageTag  string `json:"docker_registry_image_tag,omitempty"`
+	DockerComposeRaw        string `json:"docker_compose_raw,omitempty"`
+
+	PortsExposes  string `json:"ports_exposes,omitempty"`
+	Domains       string `json:"domains,omitempty"`
+	InstantDeploy bool   `json:"instant_deploy"`
+}
+
+func (c *Client) createApplication(ctx context.Context, path string, req CreateApplicationRequest) (*CreateResourceResponse, error) {
+	body, err := c.doJSON(ctx, http.MethodPost, path, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var result CreateResourceResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	c.invalidateApplications("")
+	if c.cache != nil {
+		c.cache.DeletePrefix("projects:env:")
+	}
+	return &result, nil
+}
+
+// CreatePublicApplication creates an application from a public git repository.
+func (c *Client) CreatePublicApplication(req CreateApplicationRequest) (*CreateResourceResponse, error) {
+	return c.CreatePublicApplicationWithContext(c.baseContext(), req)
+}
+
+func (c *Client) CreatePublicApplicationWithContext(ctx context.Context, req CreateApplicationRequest) (*CreateResourceResponse, error) {
+	return c.createApplication(ctx, "/applications/public", req)
+}
+
+// CreatePrivateGitHubAppApplication creates an application from a private repository
+// accessed through the GitHub App in req.GitHubAppUUID.
+func (c *Client) CreatePrivateGitHubAppApplication(req CreateApplicationRequest) (*CreateResourceResponse, error) {
+	return c.CreatePrivateGitHubAppApplicationWithContext(c.baseContext(), req)
+}
+
+func (c *Client) CreatePrivateGitHubAppApplicationWithContext(ctx context.Context, req CreateApplicationRequest) (*CreateResourceResponse, error) {
+	return c.createApplication(ctx, "/applications/private-github-app", req)
+}
+
+// CreatePrivateDeployKeyApplication creates an application from a private repository
+// cloned with the deploy key in req.PrivateKeyUUID.
+func (c *Client) CreatePrivateDeployKeyApplication(req CreateApplicationRequest) (*CreateResourceResponse, error) {
+	return c.CreatePrivateDeployKeyApplicationWithContext(c.baseContext(), req)
+}
+
+func (c *Client) CreatePrivateDeployKeyApplicationWithContext(ctx context.Context, req CreateApplicationRequest) (*CreateResourceResponse, error) {
+	return c.createApplication(ctx, "/applications/private-deploy-key", req)
+}
+
+// CreateDockerfileApplication creates an application built from req.Dockerfile.
+// The content is sent base64 encoded as Coolify requires.
+func (c *Client) CreateDockerfileApplication(req CreateApplicationRequest) (*CreateResourceResponse, error) {
+	return c.CreateDockerfileApplicationWithContext(c.baseContext(), req)
+}
+
+func (c *Client) CreateDockerfileApplicationWithContext(ctx context.Context, req CreateApplicationRequest) (*CreateResourceResponse, error) {
+	req.Dockerfile = base64.StdEncoding.EncodeToString([]byte(req.Dockerfile))
+	return c.createApplication(ctx, "/applications/dockerfile", req)
+}
+
+// CreateDockerImageApplication creates an application running a prebuilt image.
+func (c *Client) CreateDockerImageApplication(req CreateApplicationRequest) (*CreateResourceResponse, error) {
+	return c.CreateDockerImageApplicationWithContext(c.baseContext(), req)
+}
+
+func (c *Client) CreateDockerImageApplicationWithContext(ctx context.Context, req CreateApplicationRequest) (*CreateResourceResponse, error) {
+	return c.createApplication(ctx, "/applications/dockerimage", req)
+}
+
+// CreateDockerComposeApplication creates an application from req.DockerComposeRaw.
+// The content is sent base64 encoded as Coolify requires.
+func (c *Client) CreateDockerComposeApplication(req CreateApplicationRequest) (*CreateResourceResponse, error) {
+	return c.CreateDockerComposeApplicationWithContext(c.baseContext(), req)
+}
+
+func (c *Client) CreateDockerComposeApplicationWithContext(ctx context.Context, req CreateApplicationRequest) (*CreateResourceResponse, error) {
+	req.DockerComposeRaw = base64.StdEncoding.EncodeToString([]byte(req.DockerComposeRaw))
+	return c.createApplication(ctx, "/applications/dockercompose", req)
+}
+
+func (c *Client) ListGitHubApps() ([]GitHubApp, error) {
+	return c.ListGitHubAppsWithContext(c.baseContext())
+}
+
+func (c *Client) ListGitHubAppsWithContext(ctx context.Context) ([]GitHubApp, error) {
+	body, err := c.doAPI(ctx, http.MethodGet, "/github-apps", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var apps []GitHubApp
+	if err := json.Unmarshal(body, &apps); err != nil {
+		return nil, err
+	}
+	return apps, nil
+}
+
+func (c *Client) ListPrivateKeys() ([]PrivateKey, error) {
+	return c.ListPrivateKeysWithContext(c.baseContext())
+}
+
+func (c *Client) ListPrivateKeysWithContext(ctx context.Context) ([]PrivateKey, error) {
+	body, err := c.doAPI(ctx, http.MethodGet, "/security/keys", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []PrivateKey
+	if err := json.Unmarshal(body, &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
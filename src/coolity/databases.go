@@ -0,0 +1,142 @@
+package coolify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+func (c *Client) invalidateDatabases(uuid string) {
+	if c.cache == nil {
+		return
+	}
+	c.cache.DeletePrefix("databases:list:")
+	if uuid != "" {
+		c.cache.Delete("databases:detail:" + uuid)
+	}
+}
+
+func (c *Client) GetDatabaseByUUID(uuid string) (*DatabaseDetail, error) {
+	return c.GetDatabaseByUUIDWithContext(c.baseContext(), uuid)
+}
+
+func (c *Client) GetDatabaseByUUIDWithContext(ctx context.Context, uuid string) (*DatabaseDetail, error) {
+	cacheKey := "databases:detail:" + uuid
+	if cached, ok := c.getCached(cacheKey); ok {
+		if db, ok := cached.(*DatabaseDetail); ok {
+			return db, nil
+		}
+	}
+
+	body, err := c.doAPI(ctx, http.MethodGet, "/databases/"+uuid, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var db DatabaseDetail
+	if err := json.Unmarshal(body, &db); err != nil {
+		return nil, err
+	}
+
+	c.cacheResult(cacheKey, &db)
+	return &db, nil
+}
+
+// databaseAction calls one of the lifecycle endpoints (start, stop, restart) of a database.
+func (c *Client) databaseAction(ctx context.Context, uuid, action string) (*MessageResponse, error) {
+	body, err := c.doAPI(mutating(ctx), http.MethodGet, "/databases/"+uuid+"/"+action, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result MessageResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	c.invalidateDatabases(uuid)
+	return &result, nil
+}
+
+func (c *Client) StartDatabase(uuid string) (*MessageResponse, error) {
+	return c.StartDatabaseWithContext(c.baseContext(), uuid)
+}
+
+func (c *Client) StartDatabaseWithContext(ctx context.Context, uuid string) (*MessageResponse, error) {
+	return c.databaseAction(ctx, uuid, "start")
+}
+
+func (c *Client) StopDatabase(uuid string) (*MessageResponse, error) {
+	return c.StopDatabaseWithContext(c.baseContext(), uuid)
+}
+
+func (c *Client) StopDatabaseWithContext(ctx context.Context, uuid string) (*MessageResponse, error) {
+	return c.databaseAction(ctx, uuid, "stop")
+}
+
+func (c *Client) RestartDatabase(uuid string) (*MessageResponse, error) {
+	return c.RestartDatabaseWithContext(c.baseContext(), uuid)
+}
+
+func (c *Client) RestartDatabaseWithContext(ctx context.Context, uuid string) (*MessageResponse, error) {
+	return c.databaseAction(ctx, uuid, "restart")
+}
+
+func (c *Client) DeleteDatabaseByUUID(uuid string) error {
+	return c.DeleteDatabaseByUUIDWithContext(c.baseContext(), uuid)
+}
+
+func (c *Client) DeleteDatabaseByUUIDWithContext(ctx context.Context, uuid string) error {
+	if _, err := c.doAPI(ctx, http.MethodDelete, "/databases/"+uuid, nil, nil); err != nil {
+		return err
+	}
+
+	c.invalidateDatabases(uuid)
+	return nil
+}
+
+func (c *Client) ListDatabaseBackups(uuid string) ([]ScheduledBackup, error) {
+	return c.ListDatabaseBackupsWithContext(c.baseContext(), uuid)
+}
+
+func (c *Client) ListDatabaseBackupsWithContext(ctx context.Context, uuid string) ([]ScheduledBackup, error) {
+	body, err := c.doAPI(ctx, http.MethodGet, "/databases/"+uuid+"/backups", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []ScheduledBackup
+	if err := json.Unmarshal(body, &backups); err != nil {
+		return nil, err
+	}
+	return backups, nil
+}
+
+func (c *Client) ListBackupExecutions(uuid, backupUUID string) ([]BackupExecution, error) {
+	return c.ListBackupExecutionsWithContext(c.baseContext(), uuid, backupUUID)
+}
+
+func (c *Client) ListBackupExecutionsWithContext(ctx context.Context, uuid, backupUUID string) ([]BackupExecution, error) {
+	body, err := c.doAPI(ctx, http.MethodGet, "/databases/"+uuid+"/backups/"+backupUUID+"/executions", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Executions []BackupExecution `json:"executions"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+	return result.Executions, nil
+}
+
+// TriggerDatabaseBackup runs a backup schedule immediately, outside its cron frequency.
+func (c *Client) TriggerDatabaseBackup(uuid, backupUUID string) error {
+	return c.TriggerDatabaseBackupWithContext(c.baseContext(), uuid, backupUUID)
+}
+
+func (c *Client) TriggerDatabaseBackupWithContext(ctx context.Context, uuid, backupUUID string) error {
+	_, err := c.doJSON(ctx, http.MethodPatch, "/databases/"+uuid+"/backups/"+backupUUID, map[string]bool{"backup_now": true})
+	return err
+}
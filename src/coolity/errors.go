@@ -0,0 +1,105 @@
+package coolify
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// APIError is returned for every non-2xx response from Coolify.
+type APIError struct {
+	StatusCode int
+	Method     string
+	// Endpoint is the request path, including the /api/<version> prefix.
+	Endpoint string
+	// Message is Coolify's "message" field, or the raw body when it isn't JSON.
+	Message string
+	// Errors holds per-field validation messages from Coolify's "errors" field.
+	Errors map[string][]string
+}
+
+func newAPIError(req *http.Request, resp *http.Response, body []byte) *APIError {
+	e := &APIError{
+		StatusCode: resp.StatusCode,
+		Method:     req.Method,
+		Endpoint:   req.URL.Path,
+	}
+
+	var payload struct {
+		Message string              `json:"message"`
+		Errors  map[string][]string `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &payload); err == nil {
+		e.Message, e.Errors = payload.Message, payload.Errors
+	} else {
+		e.Message = strings.TrimSpace(string(body))
+	}
+	return e
+}
+
+func (e *APIError) Error() string {
+	text := fmt.Sprintf("%s %s: %d %s", e.Method, e.Endpoint, e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Message != "" {
+		text += ": " + e.Message
+	}
+	if details := e.ValidationDetails(); details != "" {
+		text += " (" + details + ")"
+	}
+	return text
+}
+
+// ValidationDetails flattens Errors into "field: message; ..." sorted by field.
+func (e *APIError) ValidationDetails() string {
+	fields := make([]string, 0, len(e.Errors))
+	for field := range e.Errors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	parts := make([]string, 0, len(fields))
+	for _, field := range fields {
+		parts = append(parts, field+": "+strings.Join(e.Errors[field], ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+func asAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	ok := errors.As(err, &apiErr)
+	return apiErr, ok
+}
+
+func IsNotFound(err error) bool {
+	e, ok := asAPIError(err)
+	return ok && e.StatusCode == http.StatusNotFound
+}
+
+// IsUnauthorized reports a missing, invalid or insufficiently privileged token.
+// Coolify answers an invalid token with 400 "Invalid token." rather than 401.
+func IsUnauthorized(err error) bool {
+	e, ok := asAPIError(err)
+	if !ok {
+		return false
+	}
+	switch e.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return true
+	case http.StatusBadRequest:
+		return len(e.Errors) == 0 && strings.Contains(strings.ToLower(e.Message), "token")
+	}
+	return false
+}
+
+// IsValidation reports a request Coolify rejected because of its input.
+func IsValidation(err error) bool {
+	e, ok := asAPIError(err)
+	return ok && (e.StatusCode == http.StatusUnprocessableEntity || len(e.Errors) > 0)
+}
+
+func IsRateLimited(err error) bool {
+	e, ok := asAPIError(err)
+	return ok && e.StatusCode == http.StatusTooManyRequests
+}
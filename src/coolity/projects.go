@@ -0,0 +1,90 @@
+package coolify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+// ListProjects returns every project; Coolify does not paginate this endpoint.
+func (c *Client) ListProjects() ([]Project, error) {
+	return c.ListProjectsWithContext(c.baseContext())
+}
+
+func (c *Client) ListProjectsWithContext(ctx context.Context) ([]Project, error) {
+	cacheKey := "projects:list"
+	if cached, ok := c.getCached(cacheKey); ok {
+		if projects, ok := cached.([]Project); ok {
+			return projects, nil
+		}
+	}
+
+	body, err := c.doAPI(ctx, http.MethodGet, "/projects", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var projects []Project
+	if err := json.Unmarshal(body, &projects); err != nil {
+		return nil, err
+	}
+
+	c.cacheResult(cacheKey, projects)
+	return projects, nil
+}
+
+// GetProject returns a project with its environments.
+func (c *Client) GetProject(uuid string) (*ProjectDetail, error) {
+	return c.GetProjectWithContext(c.baseContext(), uuid)
+}
+
+func (c *Client) GetProjectWithContext(ctx context.Context, uuid string) (*ProjectDetail, error) {
+	cacheKey := "projects:detail:" + uuid
+	if cached, ok := c.getCached(cacheKey); ok {
+		if project, ok := cached.(*ProjectDetail); ok {
+			return project, nil
+		}
+	}
+
+	body, err := c.doAPI(ctx, http.MethodGet, "/projects/"+uuid, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var project ProjectDetail
+	if err := json.Unmarshal(body, &project); err != nil {
+		return nil, err
+	}
+
+	c.cacheResult(cacheKey, &project)
+	return &project, nil
+}
+
+// GetProjectEnvironment returns an environment of a project with the resources it contains.
+// environment may be the environment's name or UUID.
+func (c *Client) GetProjectEnvironment(projectUUID, environment string) (*EnvironmentDetail, error) {
+	return c.GetProjectEnvironmentWithContext(c.baseContext(), projectUUID, environment)
+}
+
+func (c *Client) GetProjectEnvironmentWithContext(ctx context.Context, projectUUID, environment string) (*EnvironmentDetail, error) {
+	cacheKey := "projects:env:" + projectUUID + ":" + environment
+	if cached, ok := c.getCached(cacheKey); ok {
+		if env, ok := cached.(*EnvironmentDetail); ok {
+			return env, nil
+		}
+	}
+
+	body, err := c.doAPI(ctx, http.MethodGet, "/projects/"+projectUUID+"/"+url.PathEscape(environment), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var env EnvironmentDetail
+	if err := json.Unmarshal(body, &env); err != nil {
+		return nil, err
+	}
+
+	c.cacheResult(cacheKey, &env)
+	return &env, nil
+}
@@ -0,0 +1,165 @@
+package coolify
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how transient failures (network errors, 429, 502, 503
+// and 504) are retried. Only idempotent calls are retried unless the caller
+// opts in with AllowRetry.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts; 1 or less disables retries.
+	MaxAttempts int
+	// BaseDelay is the wait before the first retry; it doubles on every attempt.
+	BaseDelay time.Duration
+	// MaxDelay caps both the backoff and a server supplied Retry-After; 0 means no cap.
+	MaxDelay time.Duration
+	// Jitter randomises each delay by up to this fraction (0 to 1) in either direction.
+	Jitter float64
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    10 * time.Second,
+	Jitter:      0.2,
+}
+
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+type retryKey struct{}
+
+// AllowRetry opts a mutating call made with ctx into the client's retry policy.
+// Only use it for calls that are safe to repeat.
+func AllowRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retryKey{}, true)
+}
+
+// mutating marks a call that changes state even though Coolify exposes it as a
+// GET (start, stop, restart, ...), so it isn't retried unless the caller opted in.
+func mutating(ctx context.Context) context.Context {
+	if _, set := ctx.Value(retryKey{}).(bool); set {
+		return ctx
+	}
+	return context.WithValue(ctx, retryKey{}, false)
+}
+
+func retryable(req *http.Request) bool {
+	if req.Body != nil && req.GetBody == nil {
+		return false
+	}
+	if allowed, ok := req.Context().Value(retryKey{}).(bool); ok {
+		return allowed
+	}
+	return req.Method == http.MethodGet || req.Method == http.MethodHead
+}
+
+func transient(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		// The caller gave up; only failures of the attempt itself are worth retrying.
+		return req.Context().Err() == nil
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// delay returns how long to wait after the given failed attempt (1-based).
+// A Retry-After header takes precedence over the exponential backoff.
+func (p RetryPolicy) delay(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if after, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			return p.capped(after)
+		}
+	}
+
+	shift := attempt - 1
+	d := p.BaseDelay << shift
+	if shift >= 63 || d>>shift != p.BaseDelay {
+		// The shift overflowed.
+		d = math.MaxInt64
+	}
+	d = p.capped(d)
+	if p.Jitter > 0 {
+		d += time.Duration((rand.Float64()*2 - 1) * p.Jitter * float64(d))
+	}
+	return d
+}
+
+func (p RetryPolicy) capped(d time.Duration) time.Duration {
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		return p.MaxDelay
+	}
+	return d
+}
+
+// retryAfter parses a Retry-After value given either in seconds or as an HTTP date.
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if sec, err := strconv.Atoi(value); err == nil && sec >= 0 {
+		return time.Duration(sec) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
+
+// send performs req, retrying transient failures according to the client's policy.
+// The last response is returned as is so the caller can report its status.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	attempts := 1
+	if retryable(req) {
+		attempts = max(c.retry.MaxAttempts, 1)
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.Client.Do(req)
+		if attempt >= attempts || !transient(req, resp, err) {
+			return resp, err
+		}
+
+		wait := c.retry.delay(attempt, resp)
+		reason := fmt.Sprint(err)
+		if resp != nil {
+			reason = resp.Status
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4<<10))
+			resp.Body.Close()
+		}
+		if c.Debug {
+			log.Printf("[coolify] %s %s failed (%s), retry %d/%d in %s", req.Method, req.URL.String(), reason, attempt, attempts-1, wait.Round(time.Millisecond))
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package coolify
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"", 0, false},
+		{"0", 0, true},
+		{"3", 3 * time.Second, true},
+		{"-1", 0, false},
+		{"soon", 0, false},
+		{time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), 0, true},
+	}
+	for _, tt := range tests {
+		got, ok := retryAfter(tt.value)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("retryAfter(%q) = %v, %v; want %v, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	// HTTP dates have second precision, so allow for the truncation.
+	got, ok := retryAfter(time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+	if !ok || got < 58*time.Second || got > time.Minute {
+		t.Errorf("retryAfter(date in a minute) = %v, %v", got, ok)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	p := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	tests := []struct {
+		attempt    int
+		retryAfter string
+		want       time.Duration
+	}{
+		{1, "", 100 * time.Millisecond},
+		{2, "", 200 * time.Millisecond},
+		{4, "", 800 * time.Millisecond},
+		{5, "", time.Second},
+		// The shift overflows an int64 long before these attempts.
+		{40, "", time.Second},
+		{64, "", time.Second},
+		{200, "", time.Second},
+		{1, "0", 0},
+		{1, "2", time.Second},
+		{3, "bogus", 400 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		var resp *http.Response
+		if tt.retryAfter != "" {
+			resp = &http.Response{Header: http.Header{"Retry-After": {tt.retryAfter}}}
+		}
+		if got := p.delay(tt.attempt, resp); got != tt.want {
+			t.Errorf("delay(%d, Retry-After %q) = %v, want %v", tt.attempt, tt.retryAfter, got, tt.want)
+		}
+	}
+
+	uncapped := RetryPolicy{BaseDelay: time.Second}
+	if got := uncapped.delay(100, nil); got <= 0 {
+		t.Errorf("uncapped delay after overflow = %v, want a positive delay", got)
+	}
+	if got := uncapped.delay(1, &http.Response{Header: http.Header{"Retry-After": {"90"}}}); got != 90*time.Second {
+		t.Errorf("uncapped Retry-After = %v, want 1m30s", got)
+	}
+}
+
+func TestRetryDelayJitter(t *testing.T) {
+	p := RetryPolicy{BaseDelay: time.Second, MaxDelay: 10 * time.Second, Jitter: 0.2}
+	for i := 0; i < 100; i++ {
+		if got := p.delay(2, nil); got < 1600*time.Millisecond || got > 2400*time.Millisecond {
+			t.Fatalf("delay with 20%% jitter = %v, want within 1.6s-2.4s", got)
+		}
+	}
+}
+
+// flakyServer answers the version probe and then fails every other request
+// with 503 and the given Retry-After until failures is used up.
+func flakyServer(t *testing.T, failures int32, retryAfter string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/version") {
+			_, _ = w.Write([]byte("4.0.0"))
+			return
+		}
+		if calls.Add(1) <= failures {
+			w.Header().Set("Retry-After", retryAfter)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"message":"ok"}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+func newRetryTestClient(srv *httptest.Server, maxDelay time.Duration) *Client {
+	return NewClient(srv.URL, "token",
+		WithAPIVersion("v4"),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: maxDelay}),
+	)
+}
+
+func TestSendRetriesServiceUnavailable(t *testing.T) {
+	srv, calls := flakyServer(t, 2, "0")
+	c := newRetryTestClient(srv, 50*time.Millisecond)
+
+	if _, err := c.doAPI(context.Background(), http.MethodGet, "/servers", nil, nil); err != nil {
+		t.Fatalf("GET after two 503s: %v", err)
+	}
+	if n := calls.Load(); n != 3 {
+		t.Errorf("attempts = %d, want 3", n)
+	}
+}
+
+func TestSendCapsRetryAfter(t *testing.T) {
+	srv, calls := flakyServer(t, 1, "120")
+	c := newRetryTestClient(srv, 20*time.Millisecond)
+
+	start := time.Now()
+	if _, err := c.doAPI(context.Background(), http.MethodGet, "/servers", nil, nil); err != nil {
+		t.Fatalf("GET after a 503: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond || elapsed > 5*time.Second {
+		t.Errorf("waited %v, want the 20ms cap instead of Retry-After: 120", elapsed)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("attempts = %d, want 2", n)
+	}
+}
+
+func TestSendGivesUpAfterMaxAttempts(t *testing.T) {
+	srv, calls := flakyServer(t, 10, "0")
+	c := newRetryTestClient(srv, 10*time.Millisecond)
+
+	_, err := c.doAPI(context.Background(), http.MethodGet, "/servers", nil, nil)
+	if e, ok := asAPIError(err); !ok || e.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("err = %v, want a 503 APIError", err)
+	}
+	if n := calls.Load(); n != 3 {
+		t.Errorf("attempts = %d, want 3", n)
+	}
+}
+
+func TestSendDoesNotRetryMutatingCalls(t *testing.T) {
+	tests := []struct {
+		name   string
+		call   func(c *Client) error
+		wanted int32
+	}{
+		{"restart", func(c *Client) error {
+			_, err := c.RestartApplicationByUUIDWithContext(context.Background(), "app")
+			return err
+		}, 1},
+		{"post", func(c *Client) error {
+			_, err := c.doAPI(context.Background(), http.MethodPost, "/deploy", nil, []byte(`{}`))
+			return err
+		}, 1},
+		{"restart with AllowRetry", func(c *Client) error {
+			_, err := c.RestartApplicationByUUIDWithContext(AllowRetry(context.Background()), "app")
+			return err
+		}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, calls := flakyServer(t, 10, "0")
+			c := newRetryTestClient(srv, 10*time.Millisecond)
+
+			if err := tt.call(c); !isUnavailable(err) {
+				t.Fatalf("err = %v, want a 503 APIError", err)
+			}
+			if n := calls.Load(); n != tt.wanted {
+				t.Errorf("attempts = %d, want %d", n, tt.wanted)
+			}
+		})
+	}
+}
+
+// isUnavailable reports a 503 APIError.
+func isUnavailable(err error) bool {
+	e, ok := asAPIError(err)
+	return ok && e.StatusCode == http.StatusServiceUnavailable
+}
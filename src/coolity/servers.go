@@ -0,0 +1,99 @@
+package coolify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+func (c *Client) invalidateServers() {
+	if c.cache == nil {
+		return
+	}
+	c.cache.Delete("servers:list")
+}
+
+// ListServers returns every server; Coolify does not paginate this endpoint.
+func (c *Client) ListServers() ([]Server, error) {
+	return c.ListServersWithContext(c.baseContext())
+}
+
+func (c *Client) ListServersWithContext(ctx context.Context) ([]Server, error) {
+	cacheKey := "servers:list"
+	if cached, ok := c.getCached(cacheKey); ok {
+		if servers, ok := cached.([]Server); ok {
+			return servers, nil
+		}
+	}
+
+	body, err := c.doAPI(ctx, http.MethodGet, "/servers", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var servers []Server
+	if err := json.Unmarshal(body, &servers); err != nil {
+		return nil, err
+	}
+
+	c.cacheResult(cacheKey, servers)
+	return servers, nil
+}
+
+// GetServer is not cached: its reachable and usable flags change while a
+// validation runs in the background.
+func (c *Client) GetServer(uuid string) (*Server, error) {
+	return c.GetServerWithContext(c.baseContext(), uuid)
+}
+
+func (c *Client) GetServerWithContext(ctx context.Context, uuid string) (*Server, error) {
+	body, err := c.doAPI(ctx, http.MethodGet, "/servers/"+uuid, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var server Server
+	if err := json.Unmarshal(body, &server); err != nil {
+		return nil, err
+	}
+	return &server, nil
+}
+
+// GetServerResources lists the applications, databases and services hosted on a server.
+func (c *Client) GetServerResources(uuid string) ([]ServerResource, error) {
+	return c.GetServerResourcesWithContext(c.baseContext(), uuid)
+}
+
+func (c *Client) GetServerResourcesWithContext(ctx context.Context, uuid string) ([]ServerResource, error) {
+	body, err := c.doAPI(ctx, http.MethodGet, "/servers/"+uuid+"/resources", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resources []ServerResource
+	if err := json.Unmarshal(body, &resources); err != nil {
+		return nil, err
+	}
+	return resources, nil
+}
+
+// ValidateServer asks Coolify to re-check the SSH connection. Validation runs
+// asynchronously; the result shows up in the server's reachable/usable flags.
+func (c *Client) ValidateServer(uuid string) (*MessageResponse, error) {
+	return c.ValidateServerWithContext(c.baseContext(), uuid)
+}
+
+func (c *Client) ValidateServerWithContext(ctx context.Context, uuid string) (*MessageResponse, error) {
+	body, err := c.doAPI(mutating(ctx), http.MethodGet, "/servers/"+uuid+"/validate", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result MessageResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	c.invalidateServers()
+	return &result, nil
+}
@@ -0,0 +1,155 @@
+package coolify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+func (c *Client) invalidateServices(uuid string) {
+	if c.cache == nil {
+		return
+	}
+	c.cache.DeletePrefix("services:list:")
+	if uuid != "" {
+		c.cache.Delete("services:detail:" + uuid)
+	}
+}
+
+func (c *Client) ListServices(page, perPage int) (*Page[Service], error) {
+	return c.ListServicesWithContext(c.baseContext(), page, perPage)
+}
+
+func (c *Client) ListServicesWithContext(ctx context.Context, page, perPage int) (*Page[Service], error) {
+	query := url.Values{}
+	if page > 0 {
+		query.Set("page", strconv.Itoa(page))
+	}
+	if perPage > 0 {
+		query.Set("per_page", strconv.Itoa(perPage))
+	}
+	cacheKey := fmt.Sprintf("services:list:%d:%d", page, perPage)
+	return listPage[Service](ctx, c, "/services", query, cacheKey)
+}
+
+func (c *Client) GetServiceByUUID(uuid string) (*ServiceDetail, error) {
+	return c.GetServiceByUUIDWithContext(c.baseContext(), uuid)
+}
+
+func (c *Client) GetServiceByUUIDWithContext(ctx context.Context, uuid string) (*ServiceDetail, error) {
+	cacheKey := "services:detail:" + uuid
+	if cached, ok := c.getCached(cacheKey); ok {
+		if svc, ok := cached.(*ServiceDetail); ok {
+			return svc, nil
+		}
+	}
+
+	body, err := c.doAPI(ctx, http.MethodGet, "/services/"+uuid, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var svc ServiceDetail
+	if err := json.Unmarshal(body, &svc); err != nil {
+		return nil, err
+	}
+
+	c.cacheResult(cacheKey, &svc)
+	return &svc, nil
+}
+
+// serviceAction calls one of the lifecycle endpoints (start, stop, restart) of a service.
+func (c *Client) serviceAction(ctx context.Context, uuid, action string) (*MessageResponse, error) {
+	body, err := c.doAPI(mutating(ctx), http.MethodGet, "/services/"+uuid+"/"+action, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result MessageResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	c.invalidateServices(uuid)
+	return &result, nil
+}
+
+func (c *Client) StartService(uuid string) (*MessageResponse, error) {
+	return c.StartServiceWithContext(c.baseContext(), uuid)
+}
+
+func (c *Client) StartServiceWithContext(ctx context.Context, uuid string) (*MessageResponse, error) {
+	return c.serviceAction(ctx, uuid, "start")
+}
+
+func (c *Client) StopService(uuid string) (*MessageResponse, error) {
+	return c.StopServiceWithContext(c.baseContext(), uuid)
+}
+
+func (c *Client) StopServiceWithContext(ctx context.Context, uuid string) (*MessageResponse, error) {
+	return c.serviceAction(ctx, uuid, "stop")
+}
+
+func (c *Client) RestartService(uuid string) (*MessageResponse, error) {
+	return c.RestartServiceWithContext(c.baseContext(), uuid)
+}
+
+func (c *Client) RestartServiceWithContext(ctx context.Context, uuid string) (*MessageResponse, error) {
+	return c.serviceAction(ctx, uuid, "restart")
+}
+
+func (c *Client) GetServiceEnvsByUUID(uuid string) ([]EnvironmentVariable, error) {
+	return c.GetServiceEnvsByUUIDWithContext(c.baseContext(), uuid)
+}
+
+func (c *Client) GetServiceEnvsByUUIDWithContext(ctx context.Context, uuid string) ([]EnvironmentVariable, error) {
+	body, err := c.doAPI(ctx, http.MethodGet, "/services/"+uuid+"/envs", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var envs []EnvironmentVariable
+	if err := json.Unmarshal(body, &envs); err != nil {
+		return nil, err
+	}
+	return envs, nil
+}
+
+func (c *Client) CreateServiceEnv(uuid string, env EnvironmentVariableInput) (*CreateResourceResponse, error) {
+	return c.CreateServiceEnvWithContext(c.baseContext(), uuid, env)
+}
+
+func (c *Client) CreateServiceEnvWithContext(ctx context.Context, uuid string, env EnvironmentVariableInput) (*CreateResourceResponse, error) {
+	body, err := c.doJSON(ctx, http.MethodPost, "/services/"+uuid+"/envs", env)
+	if err != nil {
+		return nil, err
+	}
+
+	var result CreateResourceResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateServiceEnv updates the variable matching env.Key.
+func (c *Client) UpdateServiceEnv(uuid string, env EnvironmentVariableInput) error {
+	return c.UpdateServiceEnvWithContext(c.baseContext(), uuid, env)
+}
+
+func (c *Client) UpdateServiceEnvWithContext(ctx context.Context, uuid string, env EnvironmentVariableInput) error {
+	_, err := c.doJSON(ctx, http.MethodPatch, "/services/"+uuid+"/envs", env)
+	return err
+}
+
+func (c *Client) DeleteServiceEnv(uuid, envUUID string) error {
+	return c.DeleteServiceEnvWithContext(c.baseContext(), uuid, envUUID)
+}
+
+func (c *Client) DeleteServiceEnvWithContext(ctx context.Context, uuid, envUUID string) error {
+	_, err := c.doAPI(ctx, http.MethodDelete, "/services/"+uuid+"/envs/"+envUUID, nil, nil)
+	return err
+}
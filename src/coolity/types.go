@@ -1,5 +1,10 @@
 package coolify
 
+import (
+	"encoding/json"
+	"strings"
+)
+
 type Application struct {
 	ID     int64  `json:"id"`
 	UUID   string `json:"uuid"`
@@ -19,11 +24,46 @@ type ApplicationDetail struct {
 	GitBranch               string `json:"git_branch"`
 	DockerRegistryImageName string `json:"docker_registry_image_name"`
 	Dockerfile              string `json:"dockerfile"`
+	DockerRegistryImageTag  string `json:"docker_registry_image_tag"`
+	GitCommitSHA            string `json:"git_commit_sha"`
 	BuildPack               string `json:"build_pack"`
+	PortsExposes            string `json:"ports_exposes"`
+	InstallCommand          string `json:"install_command"`
+	BuildCommand            string `json:"build_command"`
+	StartCommand            string `json:"start_command"`
+	HealthCheckEnabled      bool   `json:"health_check_enabled"`
+	HealthCheckPath         string `json:"health_check_path"`
+	HealthCheckPort         string `json:"health_check_port"`
+	HealthCheckInterval     int    `json:"health_check_interval"`
+	HealthCheckTimeout      int    `json:"health_check_timeout"`
+	HealthCheckRetries      int    `json:"health_check_retries"`
 	CreatedAt               string `json:"created_at"`
 	UpdatedAt               string `json:"updated_at"`
 	// Additional resource associations
-	Environment string `json:"environment"`
+	Environment   string `json:"environment"`
+	EnvironmentID int64  `json:"environment_id"`
+}
+
+// ApplicationPatch lists the application settings that can be changed via UpdateApplication.
+// Nil fields are left untouched.
+type ApplicationPatch struct {
+	// Domains is a comma separated list of URLs; Coolify stores it as the application's FQDN.
+	Domains                *string `json:"domains,omitempty"`
+	GitBranch              *string `json:"git_branch,omitempty"`
+	GitCommitSHA           *string `json:"git_commit_sha,omitempty"`
+	BuildPack              *string `json:"build_pack,omitempty"`
+	DockerRegistryImageTag *string `json:"docker_registry_image_tag,omitempty"`
+	PortsExposes           *string `json:"ports_exposes,omitempty"`
+	InstallCommand         *string `json:"install_command,omitempty"`
+	BuildCommand           *string `json:"build_command,omitempty"`
+	StartCommand           *string `json:"start_command,omitempty"`
+
+	HealthCheckEnabled  *bool   `json:"health_check_enabled,omitempty"`
+	HealthCheckPath     *string `json:"health_check_path,omitempty"`
+	HealthCheckPort     *string `json:"health_check_port,omitempty"`
+	HealthCheckInterval *int    `json:"health_check_interval,omitempty"`
+	HealthCheckTimeout  *int    `json:"health_check_timeout,omitempty"`
+	HealthCheckRetries  *int    `json:"health_check_retries,omitempty"`
 }
 
 type ApplicationLogs struct {
@@ -49,6 +89,39 @@ type EnvironmentVariable struct {
 	UpdatedAt        string `json:"updated_at"`
 }
 
+// EnvironmentVariableInput is the payload for creating or updating an application env var.
+type EnvironmentVariableInput struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	IsPreview   bool   `json:"is_preview"`
+	IsBuildTime bool   `json:"is_build_time"`
+	IsLiteral   bool   `json:"is_literal"`
+	IsMultiline bool   `json:"is_multiline"`
+	IsShownOnce bool   `json:"is_shown_once"`
+}
+
+// Input returns the variable as an update payload. The raw Value is used so
+// references to shared variables survive the round trip.
+func (e EnvironmentVariable) Input() EnvironmentVariableInput {
+	return EnvironmentVariableInput{
+		Key:         e.Key,
+		Value:       e.Value,
+		IsPreview:   e.IsPreview,
+		IsBuildTime: e.IsBuildTime,
+		IsLiteral:   e.IsLiteral,
+		IsMultiline: e.IsMultiline,
+		IsShownOnce: e.IsShownOnce,
+	}
+}
+
+type CreateResourceResponse struct {
+	UUID string `json:"uuid"`
+}
+
+type MessageResponse struct {
+	Message string `json:"message"`
+}
+
 type StartDeploymentResponse struct {
 	Message        string `json:"message"`
 	DeploymentUUID string `json:"deployment_uuid"`
@@ -71,6 +144,73 @@ type Deployment struct {
 	Application   string `json:"application"`
 }
 
+// DeploymentDetail is a single entry of Coolify's deployment queue as returned by GET /deployments/{uuid}.
+type DeploymentDetail struct {
+	ID              int64  `json:"id"`
+	DeploymentUUID  string `json:"deployment_uuid"`
+	ApplicationName string `json:"application_name"`
+	ServerName      string `json:"server_name"`
+	Status          string `json:"status"`
+	Commit          string `json:"commit"`
+	CommitMessage   string `json:"commit_message"`
+	DeploymentURL   string `json:"deployment_url"`
+	ForceRebuild    bool   `json:"force_rebuild"`
+	RestartOnly     bool   `json:"restart_only"`
+	Logs            string `json:"logs"`
+	CreatedAt       string `json:"created_at"`
+	UpdatedAt       string `json:"updated_at"`
+}
+
+// DeploymentLogEntry is one line of the JSON-encoded build log stored in DeploymentDetail.Logs.
+type DeploymentLogEntry struct {
+	Command   string `json:"command"`
+	Output    string `json:"output"`
+	Type      string `json:"type"`
+	Timestamp string `json:"timestamp"`
+	Hidden    bool   `json:"hidden"`
+	Batch     int    `json:"batch"`
+	Order     int    `json:"order"`
+}
+
+// LogEntries decodes the build log, skipping entries Coolify marks as hidden.
+func (d DeploymentDetail) LogEntries() []DeploymentLogEntry {
+	if strings.TrimSpace(d.Logs) == "" {
+		return nil
+	}
+
+	var entries []DeploymentLogEntry
+	if err := json.Unmarshal([]byte(d.Logs), &entries); err != nil {
+		return nil
+	}
+
+	visible := entries[:0]
+	for _, e := range entries {
+		if e.Hidden || strings.TrimSpace(e.Output) == "" {
+			continue
+		}
+		visible = append(visible, e)
+	}
+	return visible
+}
+
+// IsActiveDeploymentStatus reports whether a deployment status means it is still queued or building.
+func IsActiveDeploymentStatus(status string) bool {
+	switch strings.ToLower(status) {
+	case "queued", "in_progress":
+		return true
+	}
+	return false
+}
+
+// IsFinished reports whether the deployment reached a terminal state.
+func (d DeploymentDetail) IsFinished() bool {
+	switch strings.ToLower(d.Status) {
+	case "finished", "failed", "cancelled", "cancelled-by-user", "error":
+		return true
+	}
+	return false
+}
+
 type Environment struct {
 	ID          int64  `json:"id"`
 	UUID        string `json:"uuid"`
@@ -81,6 +221,49 @@ type Environment struct {
 	UpdatedAt   string `json:"updated_at"`
 }
 
+type Project struct {
+	ID          int64  `json:"id"`
+	UUID        string `json:"uuid"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type ProjectDetail struct {
+	ID           int64         `json:"id"`
+	UUID         string        `json:"uuid"`
+	Name         string        `json:"name"`
+	Description  string        `json:"description"`
+	Environments []Environment `json:"environments"`
+}
+
+// EnvironmentDetail is an environment with its resources. Coolify returns one
+// list per database engine; Databases merges them.
+type EnvironmentDetail struct {
+	ID           int64         `json:"id"`
+	UUID         string        `json:"uuid"`
+	Name         string        `json:"name"`
+	Description  string        `json:"description"`
+	ProjectID    int64         `json:"project_id"`
+	Applications []Application `json:"applications"`
+	Services     []Service     `json:"services"`
+	Postgresqls  []Database    `json:"postgresqls"`
+	Redis        []Database    `json:"redis"`
+	Mongodbs     []Database    `json:"mongodbs"`
+	Mysqls       []Database    `json:"mysqls"`
+	Mariadbs     []Database    `json:"mariadbs"`
+	Keydbs       []Database    `json:"keydbs"`
+	Dragonflies  []Database    `json:"dragonflies"`
+	Clickhouses  []Database    `json:"clickhouses"`
+}
+
+func (e EnvironmentDetail) Databases() []Database {
+	var out []Database
+	for _, list := range [][]Database{e.Postgresqls, e.Redis, e.Mongodbs, e.Mysqls, e.Mariadbs, e.Keydbs, e.Dragonflies, e.Clickhouses} {
+		out = append(out, list...)
+	}
+	return out
+}
+
 type Database struct {
 	ID        int64  `json:"id"`
 	UUID      string `json:"uuid"`
@@ -93,6 +276,143 @@ type Database struct {
 	UpdatedAt string `json:"updated_at"`
 }
 
+// DatabaseDetail is a single database as returned by /databases/{uuid}. The connection
+// URLs include credentials and must be masked before they are shown.
+type DatabaseDetail struct {
+	ID            int64  `json:"id"`
+	UUID          string `json:"uuid"`
+	Name          string `json:"name"`
+	Description   string `json:"description"`
+	Status        string `json:"status"`
+	Type          string `json:"database_type"`
+	Image         string `json:"image"`
+	IsPublic      bool   `json:"is_public"`
+	PublicPort    int    `json:"public_port"`
+	InternalURL   string `json:"internal_db_url"`
+	ExternalURL   string `json:"external_db_url"`
+	EnvironmentID int64  `json:"environment_id"`
+	CreatedAt     string `json:"created_at"`
+	UpdatedAt     string `json:"updated_at"`
+}
+
+// ScheduledBackup is a backup schedule of a database. Coolify embeds the recent executions.
+type ScheduledBackup struct {
+	ID              int64             `json:"id"`
+	UUID            string            `json:"uuid"`
+	Enabled         bool              `json:"enabled"`
+	Frequency       string            `json:"frequency"`
+	SaveS3          bool              `json:"save_s3"`
+	S3StorageID     *int64            `json:"s3_storage_id"`
+	RetentionLocal  int               `json:"database_backup_retention_amount_locally"`
+	DatabasesBackup string            `json:"databases_to_backup"`
+	Executions      []BackupExecution `json:"executions"`
+	CreatedAt       string            `json:"created_at"`
+	UpdatedAt       string            `json:"updated_at"`
+}
+
+// LatestExecution returns the most recent execution, or nil when the schedule never ran.
+func (s ScheduledBackup) LatestExecution() *BackupExecution {
+	var latest *BackupExecution
+	for i := range s.Executions {
+		if latest == nil || s.Executions[i].ID > latest.ID {
+			latest = &s.Executions[i]
+		}
+	}
+	return latest
+}
+
+type BackupExecution struct {
+	ID           int64  `json:"id"`
+	UUID         string `json:"uuid"`
+	Status       string `json:"status"`
+	Message      string `json:"message"`
+	Size         int64  `json:"size"`
+	Filename     string `json:"filename"`
+	DatabaseName string `json:"database_name"`
+	S3Uploaded   *bool  `json:"s3_uploaded"`
+	CreatedAt    string `json:"created_at"`
+	FinishedAt   string `json:"finished_at"`
+}
+
+type Service struct {
+	ID          int64  `json:"id"`
+	UUID        string `json:"uuid"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Status      string `json:"status"`
+	ServiceType string `json:"service_type"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+// ServiceComponent is one container of a service, either an application or a database.
+type ServiceComponent struct {
+	UUID   string `json:"uuid"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	FQDN   string `json:"fqdn"`
+	Image  string `json:"image"`
+}
+
+type ServiceDetail struct {
+	ID            int64              `json:"id"`
+	UUID          string             `json:"uuid"`
+	Name          string             `json:"name"`
+	Description   string             `json:"description"`
+	Status        string             `json:"status"`
+	ServiceType   string             `json:"service_type"`
+	EnvironmentID int64              `json:"environment_id"`
+	ServerID      int64              `json:"server_id"`
+	Applications  []ServiceComponent `json:"applications"`
+	Databases     []ServiceComponent `json:"databases"`
+	CreatedAt     string             `json:"created_at"`
+	UpdatedAt     string             `json:"updated_at"`
+}
+
+type Server struct {
+	ID          int64          `json:"id"`
+	UUID        string         `json:"uuid"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	IP          string         `json:"ip"`
+	User        string         `json:"user"`
+	Port        int            `json:"port"`
+	ProxyType   string         `json:"proxy_type"`
+	Settings    ServerSettings `json:"settings"`
+	CreatedAt   string         `json:"created_at"`
+	UpdatedAt   string         `json:"updated_at"`
+}
+
+type ServerSettings struct {
+	IsReachable     bool `json:"is_reachable"`
+	IsUsable        bool `json:"is_usable"`
+	IsBuildServer   bool `json:"is_build_server"`
+	ConcurrentBuild int  `json:"concurrent_builds"`
+}
+
+// ServerResource is an application, database or service running on a server.
+type ServerResource struct {
+	ID     int64  `json:"id"`
+	UUID   string `json:"uuid"`
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Status string `json:"status"`
+}
+
+type GitHubApp struct {
+	ID           int64  `json:"id"`
+	UUID         string `json:"uuid"`
+	Name         string `json:"name"`
+	Organization string `json:"organization"`
+}
+
+type PrivateKey struct {
+	ID          int64  `json:"id"`
+	UUID        string `json:"uuid"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
 type Pagination struct {
 	CurrentPage int `json:"current_page"`
 	LastPage    int `json:"last_page"`
@@ -0,0 +1,116 @@
+package coolify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// probeRetryInterval limits how often requests trigger a probe while none has succeeded.
+const probeRetryInterval = time.Minute
+
+// VersionInfo is the result of a successful version probe.
+type VersionInfo struct {
+	APIVersion     string
+	CoolifyVersion string
+	ProbedAt       time.Time
+}
+
+// Version returns the last successful probe, or nil if none has succeeded yet.
+func (c *Client) Version() *VersionInfo {
+	return c.version.Load()
+}
+
+// ProbeVersion asks each candidate API version for Coolify's version and keeps
+// the first one that answers. Later requests use it until the next probe.
+func (c *Client) ProbeVersion() (*VersionInfo, error) {
+	return c.ProbeVersionWithContext(c.baseContext())
+}
+
+func (c *Client) ProbeVersionWithContext(ctx context.Context) (*VersionInfo, error) {
+	c.probeMu.Lock()
+	c.lastProbe = time.Now()
+	c.probeMu.Unlock()
+	return c.probe(ctx)
+}
+
+// probe runs without probeMu held, so a slow Coolify never blocks other requests.
+func (c *Client) probe(ctx context.Context) (*VersionInfo, error) {
+	ctx, cancel := c.withRequestTimeout(ctx)
+	defer cancel()
+
+	var lastErr error
+	for _, version := range c.versionsToTry() {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.apiURLWithVersion(version, "/version", nil), nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", "application/json")
+
+		body, err := c.do(req)
+		if err != nil {
+			if !IsNotFound(err) {
+				return nil, err
+			}
+			lastErr = err
+			if c.Debug {
+				log.Printf("[coolify] API %s not available, trying next version", version)
+			}
+			continue
+		}
+
+		info := &VersionInfo{APIVersion: version, CoolifyVersion: parseVersion(body), ProbedAt: time.Now()}
+		c.version.Store(info)
+		if c.Debug {
+			log.Printf("[coolify] detected Coolify %s on API %s", info.CoolifyVersion, version)
+		}
+		return info, nil
+	}
+	return nil, fmt.Errorf("no supported API version found: %w", lastErr)
+}
+
+// negotiatedVersion returns the API version for a request. Until a probe
+// succeeds, one request per probeRetryInterval probes; every other request
+// uses the configured version rather than waiting for it.
+func (c *Client) negotiatedVersion(ctx context.Context) string {
+	if info := c.version.Load(); info != nil {
+		return info.APIVersion
+	}
+
+	c.probeMu.Lock()
+	start := !c.probing && time.Since(c.lastProbe) >= probeRetryInterval
+	if start {
+		c.probing, c.lastProbe = true, time.Now()
+	}
+	c.probeMu.Unlock()
+	if !start {
+		return c.versionsToTry()[0]
+	}
+
+	defer func() {
+		c.probeMu.Lock()
+		c.probing = false
+		c.probeMu.Unlock()
+	}()
+	info, err := c.probe(ctx)
+	if err != nil {
+		if c.Debug {
+			log.Printf("[coolify] version probe failed: %v", err)
+		}
+		return c.versionsToTry()[0]
+	}
+	return info.APIVersion
+}
+
+// parseVersion accepts the version as plain text or as a JSON string.
+func parseVersion(body []byte) string {
+	var version string
+	if err := json.Unmarshal(body, &version); err == nil {
+		return strings.TrimSpace(version)
+	}
+	return strings.TrimSpace(string(body))
+}
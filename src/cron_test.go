@@ -0,0 +1,156 @@
+package src
+
+import (
+	"testing"
+	"time"
+)
+
+// bits builds the bit set of a cron field from its values.
+func bits(values ...int) uint64 {
+	var set uint64
+	for _, v := range values {
+		set |= 1 << uint(v)
+	}
+	return set
+}
+
+func TestParseCronField(t *testing.T) {
+	tests := []struct {
+		field    string
+		min, max int
+		want     uint64
+	}{
+		{"*", 0, 6, bits(0, 1, 2, 3, 4, 5, 6)},
+		{"5", 0, 59, bits(5)},
+		{"1,3,5", 0, 59, bits(1, 3, 5)},
+		{"10-13", 0, 59, bits(10, 11, 12, 13)},
+		{"*/15", 0, 59, bits(0, 15, 30, 45)},
+		{"0-10/5", 0, 59, bits(0, 5, 10)},
+		{"50/4", 0, 59, bits(50, 54, 58)},
+		{"1-2,20-21/1", 0, 23, bits(1, 2, 20, 21)},
+	}
+	for _, tt := range tests {
+		got, err := parseCronField(tt.field, tt.min, tt.max)
+		if err != nil {
+			t.Errorf("parseCronField(%q) error: %v", tt.field, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseCronField(%q) = %b, want %b", tt.field, got, tt.want)
+		}
+	}
+}
+
+func TestParseCron(t *testing.T) {
+	tests := []struct {
+		spec string
+		want cronSpec
+	}{
+		{"* * * * *", cronSpec{
+			minute: bits(rangeOf(0, 59)...), hour: bits(rangeOf(0, 23)...), dom: bits(rangeOf(1, 31)...),
+			month: bits(rangeOf(1, 12)...), dow: bits(rangeOf(0, 7)...), domStar: true, dowStar: true,
+		}},
+		{"30 2 * * 7", cronSpec{
+			minute: bits(30), hour: bits(2), dom: bits(rangeOf(1, 31)...),
+			month: bits(rangeOf(1, 12)...), dow: bits(0, 7), domStar: true,
+		}},
+		{"0 0 1,15 * 1-5", cronSpec{
+			minute: bits(0), hour: bits(0), dom: bits(1, 15),
+			month: bits(rangeOf(1, 12)...), dow: bits(1, 2, 3, 4, 5),
+		}},
+		{"0 0 */2 * *", cronSpec{
+			minute: bits(0), hour: bits(0), dom: bits(1, 3, 5, 7, 9, 11, 13, 15, 17, 19, 21, 23, 25, 27, 29, 31),
+			month: bits(rangeOf(1, 12)...), dow: bits(rangeOf(0, 7)...), domStar: true, dowStar: true,
+		}},
+		{"@daily", cronSpec{
+			minute: bits(0), hour: bits(0), dom: bits(rangeOf(1, 31)...),
+			month: bits(rangeOf(1, 12)...), dow: bits(rangeOf(0, 7)...), domStar: true, dowStar: true,
+		}},
+		{" @Weekly ", cronSpec{
+			minute: bits(0), hour: bits(0), dom: bits(rangeOf(1, 31)...),
+			month: bits(rangeOf(1, 12)...), dow: bits(0), domStar: true,
+		}},
+	}
+	for _, tt := range tests {
+		got, err := parseCron(tt.spec)
+		if err != nil {
+			t.Errorf("parseCron(%q) error: %v", tt.spec, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseCron(%q) = %+v, want %+v", tt.spec, got, tt.want)
+		}
+	}
+}
+
+func TestParseCronInvalid(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"*/x * * * *",
+		"a * * * *",
+		"1-b * * * *",
+		"@yearly",
+	} {
+		if _, err := parseCron(spec); err == nil {
+			t.Errorf("parseCron(%q) succeeded, want an error", spec)
+		}
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	// 2024-03-15 was a Friday.
+	from := time.Date(2024, 3, 15, 10, 30, 45, 0, time.UTC)
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2024, 3, 15, 10, 31, 0, 0, time.UTC)},
+		{"30 10 * * *", time.Date(2024, 3, 16, 10, 30, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2024, 3, 15, 10, 45, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2024, 3, 17, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 0", time.Date(2024, 3, 17, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)},
+		// Both day fields restricted: either one matching is enough.
+		{"0 0 20 * 1", time.Date(2024, 3, 18, 0, 0, 0, 0, time.UTC)},
+		// A stepped field starting with "*" counts as unrestricted, so both must match.
+		{"0 0 */2 * 1", time.Date(2024, 3, 25, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 * */7", time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		spec, err := parseCron(tt.spec)
+		if err != nil {
+			t.Fatalf("parseCron(%q) error: %v", tt.spec, err)
+		}
+		got, ok := spec.next(from)
+		if !ok || !got.Equal(tt.want) {
+			t.Errorf("next(%q) = %v, %v; want %v", tt.spec, got, ok, tt.want)
+		}
+	}
+}
+
+func TestCronNextNoMatch(t *testing.T) {
+	spec, err := parseCron("0 0 31 2 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := spec.next(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)); ok {
+		t.Errorf("next = %v, want no match", got)
+	}
+}
+
+func rangeOf(lo, hi int) []int {
+	values := make([]int, 0, hi-lo+1)
+	for v := lo; v <= hi; v++ {
+		values = append(values, v)
+	}
+	return values
+}
@@ -0,0 +1,145 @@
+package src
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"coolifymanager/src/config"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+)
+
+// maskConnectionURL hides the password of a database URL, keeping user, host and port visible.
+func maskConnectionURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "(unparseable URL hidden)"
+	}
+	return u.Redacted()
+}
+
+func databaseName(reqCtx context.Context, uuid string) (string, error) {
+	db, err := config.Coolify.GetDatabaseByUUIDWithContext(reqCtx, uuid)
+	if err != nil {
+		return "", err
+	}
+	return db.Name, nil
+}
+
+func databaseListMarkup() gotgbot.InlineKeyboardMarkup {
+	return gotgbot.InlineKeyboardMarkup{InlineKeyboard: [][]gotgbot.InlineKeyboardButton{
+		{{Text: "🔙 Back to databases", CallbackData: "list_databases:1"}},
+	}}
+}
+
+func databaseMenuHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	cb := ctx.CallbackQuery
+	if !ensureAllowed(b, ctx) {
+		return nil
+	}
+	_, _ = cb.Answer(b, nil)
+
+	parts := strings.Split(strings.TrimPrefix(cb.Data, "db_menu:"), ":")
+	uuid := parts[0]
+	fromPage := 1
+	if len(parts) > 1 {
+		if p, err := strconv.Atoi(parts[1]); err == nil && p > 0 {
+			fromPage = p
+		}
+	}
+
+	db, err := config.Coolify.GetDatabaseByUUIDWithContext(updateContext(ctx), uuid)
+	if err != nil {
+		_, _, err = cb.Message.EditText(b, "❌ Failed to load database: "+errorText(err), nil)
+		return err
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("<b>🗄 %s</b>\n📄 Status: <code>%s</code>\n", html.EscapeString(db.Name), db.Status))
+	if db.Type != "" {
+		sb.WriteString(fmt.Sprintf("🏷 Type: %s\n", html.EscapeString(db.Type)))
+	}
+	if db.Image != "" {
+		sb.WriteString(fmt.Sprintf("🐳 Image: <code>%s</code>\n", html.EscapeString(db.Image)))
+	}
+	if db.InternalURL != "" {
+		sb.WriteString(fmt.Sprintf("🔒 Internal: <code>%s</code>\n", html.EscapeString(maskConnectionURL(db.InternalURL))))
+	}
+	if db.IsPublic && db.ExternalURL != "" {
+		sb.WriteString(fmt.Sprintf("🌐 Public: <code>%s</code>\n", html.EscapeString(maskConnectionURL(db.ExternalURL))))
+	}
+	if schedules, err := config.Coolify.ListDatabaseBackupsWithContext(updateContext(ctx), uuid); err == nil {
+		if last := latestBackup(schedules); last != nil {
+			sb.WriteString("💾 Last backup: " + describeExecution(*last) + "\n")
+		}
+	}
+	sb.WriteString(fmt.Sprintf("🆔 UUID: <code>%s</code>", db.UUID))
+
+	btns := [][]gotgbot.InlineKeyboardButton{
+		{{Text: "▶️ Start", CallbackData: "db_start:" + uuid}, {Text: "🔄 Restart", CallbackData: "db_restart:" + uuid}},
+		{{Text: "💾 Backups", CallbackData: "db_backups:" + uuid}},
+		{{Text: "🛑 Stop", CallbackData: "db_stop:" + uuid}, {Text: "🗑 Delete", CallbackData: "db_del:" + uuid}},
+		{{Text: "🔙 Back", CallbackData: fmt.Sprintf("list_databases:%d", fromPage)}},
+	}
+
+	_, _, err = cb.Message.EditText(b, sb.String(), &gotgbot.EditMessageTextOpts{
+		ParseMode:   "HTML",
+		ReplyMarkup: gotgbot.InlineKeyboardMarkup{InlineKeyboard: btns},
+	})
+	return err
+}
+
+// databaseLifecycleHandler handles db_start and db_restart, which need no confirmation.
+func databaseLifecycleHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	cb := ctx.CallbackQuery
+	if !ensureAllowed(b, ctx) {
+		return nil
+	}
+	_, _ = cb.Answer(b, nil)
+
+	action, uuid, _ := strings.Cut(cb.Data, ":")
+	call, icon := config.Coolify.StartDatabaseWithContext, "▶️"
+	if action == "db_restart" {
+		call, icon = config.Coolify.RestartDatabaseWithContext, "🔄"
+	}
+
+	res, err := call(updateContext(ctx), uuid)
+	if err != nil {
+		recordAudit(b, ctx.EffectiveUser, action, uuid, "", "", err)
+		_, _, err = cb.Message.EditText(b, "❌ Database action failed: "+errorText(err), nil)
+		return err
+	}
+	recordAudit(b, ctx.EffectiveUser, action, uuid, "", res.Message, nil)
+
+	_, _, err = cb.Message.EditText(b, icon+" "+res.Message, &gotgbot.EditMessageTextOpts{
+		ReplyMarkup: gotgbot.InlineKeyboardMarkup{InlineKeyboard: [][]gotgbot.InlineKeyboardButton{
+			{{Text: "🔙 Back", CallbackData: "db_menu:" + uuid}},
+		}},
+	})
+	return err
+}
+
+func databaseStopHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	cb := ctx.CallbackQuery
+	if !ensureAllowed(b, ctx) {
+		return nil
+	}
+	_, _ = cb.Answer(b, nil)
+
+	return askConfirmation(b, ctx, "db_stop", strings.TrimPrefix(cb.Data, "db_stop:"))
+}
+
+func databaseDeleteHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	cb := ctx.CallbackQuery
+	if !ensureAllowed(b, ctx) {
+		return nil
+	}
+	_, _ = cb.Answer(b, nil)
+
+	return askConfirmation(b, ctx, "db_delete", strings.TrimPrefix(cb.Data, "db_del:"))
+}
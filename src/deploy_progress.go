@@ -0,0 +1,227 @@
+package src
+
+import (
+	"fmt"
+	"html"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"coolifymanager/src/config"
+	coolifyPkg "coolifymanager/src/coolity"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+)
+
+const (
+	deployPollInterval = 5 * time.Second
+	deployPollTimeout  = 30 * time.Minute
+	deployLogTailLines = 6
+	deployLogLineLimit = 180
+)
+
+// activeTrackers prevents two goroutines from editing the same message for one deployment.
+var activeTrackers sync.Map
+
+// trackDeployment polls a deployment until it finishes and keeps the given message
+// updated with its status, elapsed time and the tail of the build log. Extra rows
+// stay on the message alongside the cancel and back buttons.
+func trackDeployment(b *gotgbot.Bot, chatID, messageID int64, appUUID, deploymentUUID, title string, extra ...[]gotgbot.InlineKeyboardButton) {
+	if deploymentUUID == "" {
+		return
+	}
+	if _, loaded := activeTrackers.LoadOrStore(deploymentUUID, struct{}{}); loaded {
+		return
+	}
+
+	go func() {
+		defer activeTrackers.Delete(deploymentUUID)
+
+		started := time.Now()
+		ticker := time.NewTicker(deployPollInterval)
+		defer ticker.Stop()
+
+		var lastText string
+		failures := 0
+		for {
+			deployment, err := config.Coolify.GetDeploymentByUUID(deploymentUUID)
+			if err != nil {
+				failures++
+				log.Printf("deploy tracker %s: %v", deploymentUUID, err)
+				if failures >= 5 {
+					return
+				}
+			} else {
+				failures = 0
+				text := renderDeploymentProgress(title, deployment, time.Since(started))
+				if text != lastText {
+					lastText = text
+					_, _, editErr := b.EditMessageText(text, &gotgbot.EditMessageTextOpts{
+						ChatId:      chatID,
+						MessageId:   messageID,
+						ParseMode:   "HTML",
+						ReplyMarkup: deploymentProgressMarkup(appUUID, deployment, extra),
+					})
+					if editErr != nil && !strings.Contains(editErr.Error(), "message is not modified") {
+						log.Printf("deploy tracker %s: failed to edit message: %v", deploymentUUID, editErr)
+					}
+				}
+				if deployment.IsFinished() {
+					return
+				}
+			}
+
+			if time.Since(started) > deployPollTimeout {
+				return
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+func renderDeploymentProgress(title string, d *coolifyPkg.DeploymentDetail, elapsed time.Duration) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("<b>%s %s</b>\n", deploymentStatusIcon(d.Status), html.EscapeString(title)))
+	if d.ApplicationName != "" {
+		sb.WriteString(fmt.Sprintf("📦 %s\n", html.EscapeString(d.ApplicationName)))
+	}
+	sb.WriteString(fmt.Sprintf("📄 Status: <code>%s</code>\n", html.EscapeString(strings.ToUpper(d.Status))))
+	sb.WriteString(fmt.Sprintf("⏱ Elapsed: <code>%s</code>\n", elapsed.Truncate(time.Second)))
+	sb.WriteString(fmt.Sprintf("🆔 <code>%s</code>\n", d.DeploymentUUID))
+	if d.Commit != "" && d.Commit != "HEAD" {
+		sb.WriteString(fmt.Sprintf("🔖 Commit: <code>%s</code>\n", html.EscapeString(shortCommit(d.Commit))))
+	}
+
+	entries := d.LogEntries()
+	if len(entries) > deployLogTailLines {
+		entries = entries[len(entries)-deployLogTailLines:]
+	}
+	if len(entries) > 0 {
+		var lines []string
+		for _, e := range entries {
+			for _, line := range strings.Split(strings.TrimSpace(e.Output), "\n") {
+				line = strings.TrimSpace(line)
+				if line == "" {
+					continue
+				}
+				lines = append(lines, truncateText(line, deployLogLineLimit))
+			}
+		}
+		if len(lines) > deployLogTailLines {
+			lines = lines[len(lines)-deployLogTailLines:]
+		}
+		sb.WriteString("\n<pre>" + html.EscapeString(strings.Join(lines, "\n")) + "</pre>")
+	}
+	return sb.String()
+}
+
+func deploymentProgressMarkup(appUUID string, d *coolifyPkg.DeploymentDetail, extra [][]gotgbot.InlineKeyboardButton) gotgbot.InlineKeyboardMarkup {
+	var rows [][]gotgbot.InlineKeyboardButton
+	if !d.IsFinished() {
+		rows = append(rows, []gotgbot.InlineKeyboardButton{
+			{Text: "✋ Cancel", CallbackData: cancelCallbackData(d.DeploymentUUID, appUUID)},
+		})
+	}
+	rows = append(rows, extra...)
+	if d.IsFinished() && appUUID != "" {
+		rows = append(rows, []gotgbot.InlineKeyboardButton{
+			{Text: "🔙 Back", CallbackData: "project_menu:" + appUUID},
+		})
+	}
+	return gotgbot.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+func cancelCallbackData(deploymentUUID, appUUID string) string {
+	if appUUID == "" {
+		return "dep_cancel:" + deploymentUUID
+	}
+	return fmt.Sprintf("dep_cancel:%s:%s", deploymentUUID, appUUID)
+}
+
+// cancelDeploymentHandler handles "dep_cancel:<deployment uuid>[:<app uuid>]". Without an
+// application UUID the caller needs a global deployer role.
+func cancelDeploymentHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	cb := ctx.CallbackQuery
+	if !ensureAllowed(b, ctx) {
+		return nil
+	}
+
+	deploymentUUID, appUUID, _ := strings.Cut(strings.TrimPrefix(cb.Data, "dep_cancel:"), ":")
+	allowed := config.GlobalRole(ctx.EffectiveUser.Id) >= config.RoleDeployer
+	if appUUID != "" {
+		allowed = isAllowed(updateContext(ctx), ctx.EffectiveUser.Id, "deploy", appUUID)
+	}
+	if !allowed {
+		_, _ = cb.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: "🚫 You are not authorized.", ShowAlert: true})
+		return nil
+	}
+
+	res, err := config.Coolify.CancelDeploymentWithContext(updateContext(ctx), deploymentUUID)
+	cancelMessage := ""
+	if err == nil {
+		cancelMessage = res.Message
+	}
+	recordAudit(b, ctx.EffectiveUser, "dep_cancel", deploymentUUID, "", cancelMessage, err)
+	if err != nil {
+		_, _ = cb.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: "❌ Cancel failed: " + errorText(err), ShowAlert: true})
+		return nil
+	}
+	_, _ = cb.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: "✋ Cancellation requested"})
+
+	// A running tracker will pick up the cancelled status on its next poll.
+	if _, tracked := activeTrackers.Load(deploymentUUID); tracked {
+		return nil
+	}
+
+	back := "list_deployments:1"
+	if appUUID != "" {
+		back = fmt.Sprintf("app_deployments:%s:1", appUUID)
+	}
+	message := res.Message
+	if message == "" {
+		message = "Deployment cancelled."
+	}
+	text := fmt.Sprintf("✋ %s\nDeployment UUID: <code>%s</code>", html.EscapeString(message), deploymentUUID)
+	_, _, err = cb.Message.EditText(b, text, &gotgbot.EditMessageTextOpts{
+		ParseMode: "HTML",
+		ReplyMarkup: gotgbot.InlineKeyboardMarkup{InlineKeyboard: [][]gotgbot.InlineKeyboardButton{
+			{{Text: "🔙 Back", CallbackData: back}},
+		}},
+	})
+	return err
+}
+
+func deploymentStatusIcon(status string) string {
+	switch strings.ToLower(status) {
+	case "queued":
+		return "⏳"
+	case "in_progress":
+		return "🔨"
+	case "finished":
+		return "✅"
+	case "failed", "error":
+		return "❌"
+	case "cancelled", "cancelled-by-user":
+		return "✋"
+	default:
+		return "🚚"
+	}
+}
+
+func shortCommit(commit string) string {
+	if len(commit) > 12 {
+		return commit[:12]
+	}
+	return commit
+}
+
+// truncateText shortens s to at most limit runes, marking the cut with an ellipsis.
+func truncateText(s string, limit int) string {
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+	return string(runes[:limit]) + "…"
+}
@@ -0,0 +1,379 @@
+package src
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"coolifymanager/src/config"
+	coolifyPkg "coolifymanager/src/coolity"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/joho/godotenv"
+)
+
+const (
+	maxEnvFileSize = 256 << 10
+	envImportTTL   = 5 * time.Minute
+
+	// maskedExportHeader starts every masked export so it can't be imported by mistake.
+	maskedExportHeader = "# Masked export: values are hidden. Do not import this file."
+)
+
+// maskedValue matches the output of maskValue.
+var maskedValue = regexp.MustCompile(`^(?:•{1,6}|..•{6} \(\d+ chars\)|\(empty\))$`)
+
+// envImportPlan is a parsed .env upload waiting for the user to apply it.
+type envImportPlan struct {
+	appUUID   string
+	userID    int64
+	upserts   []coolifyPkg.EnvironmentVariableInput
+	removed   []coolifyPkg.EnvironmentVariable
+	expiresAt time.Time
+}
+
+var (
+	importMu    sync.Mutex
+	importPlans = make(map[string]envImportPlan)
+)
+
+func storeImportPlan(p envImportPlan) string {
+	importMu.Lock()
+	defer importMu.Unlock()
+
+	now := time.Now()
+	for nonce, existing := range importPlans {
+		if now.After(existing.expiresAt) {
+			delete(importPlans, nonce)
+		}
+	}
+	nonce := newNonce()
+	importPlans[nonce] = p
+	return nonce
+}
+
+func takeImportPlan(nonce string, userID int64) (envImportPlan, bool) {
+	importMu.Lock()
+	defer importMu.Unlock()
+
+	p, ok := importPlans[nonce]
+	if !ok || p.userID != userID {
+		return envImportPlan{}, false
+	}
+	delete(importPlans, nonce)
+	return p, time.Now().Before(p.expiresAt)
+}
+
+// runtimeEnvs drops preview copies; Coolify returns a second entry per key for preview deployments.
+func runtimeEnvs(envs []coolifyPkg.EnvironmentVariable) []coolifyPkg.EnvironmentVariable {
+	var out []coolifyPkg.EnvironmentVariable
+	for _, env := range envs {
+		if !env.IsPreview {
+			out = append(out, env)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Key < out[j].Key })
+	return out
+}
+
+// formatDotenv renders variables as a .env document, quoting values that need it.
+func formatDotenv(envs []coolifyPkg.EnvironmentVariable, masked bool) string {
+	var sb strings.Builder
+	if masked {
+		sb.WriteString(maskedExportHeader + "\n")
+	}
+	for _, env := range envs {
+		value := env.Value
+		if masked {
+			value = maskValue(value)
+		}
+		sb.WriteString(env.Key + "=" + quoteDotenv(value) + "\n")
+	}
+	return sb.String()
+}
+
+// quoteDotenv quotes a value so godotenv parses it back unchanged. Single quotes
+// are literal, so they are preferred; godotenv expands $VAR and escapes inside
+// double quotes, which are only used when the value contains a single quote.
+// A value ending in a backslash cannot be quoted at all in godotenv's format.
+func quoteDotenv(value string) string {
+	if !strings.ContainsAny(value, " \t\r\n#\"'$\\=`") {
+		return value
+	}
+	if !strings.Contains(value, "'") && !strings.HasSuffix(value, `\`) {
+		return "'" + value + "'"
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, "\n", `\n`, "\r", `\r`, `"`, `\"`, `$`, `\$`).Replace(value) + `"`
+}
+
+// checkNotMasked rejects a masked export, which would overwrite every secret
+// with its mask.
+func checkNotMasked(data []byte, parsed map[string]string) error {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte(maskedExportHeader)) {
+		return errors.New("this is a masked export, its values are hidden")
+	}
+	var keys []string
+	for key, value := range parsed {
+		if maskedValue.MatchString(value) {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) > 0 {
+		sort.Strings(keys)
+		return fmt.Errorf("masked values found for %s", strings.Join(keys, ", "))
+	}
+	return nil
+}
+
+func envExportHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	cb := ctx.CallbackQuery
+	if !ensureAllowed(b, ctx) {
+		return nil
+	}
+	_, _ = cb.Answer(b, nil)
+
+	parts := strings.Split(strings.TrimPrefix(cb.Data, "env_export:"), ":")
+	appUUID := parts[0]
+	masked := len(parts) > 1 && parts[1] == "m"
+
+	app, err := config.Coolify.GetApplicationByUUIDWithContext(updateContext(ctx), appUUID)
+	if err != nil {
+		_, _, err = cb.Message.EditText(b, "❌ Failed to load project: "+errorText(err), nil)
+		return err
+	}
+	envs, err := config.Coolify.GetApplicationEnvsByUUIDWithContext(updateContext(ctx), appUUID)
+	if err != nil {
+		_, _, err = cb.Message.EditText(b, "❌ Failed to fetch env vars: "+errorText(err), nil)
+		return err
+	}
+
+	envs = runtimeEnvs(envs)
+	caption := fmt.Sprintf("📤 %d variables from <b>%s</b>", len(envs), html.EscapeString(app.Name))
+	if masked {
+		caption += " (values masked)"
+	}
+	filename := strings.ReplaceAll(app.Name, " ", "_") + ".env"
+	_, err = b.SendDocument(cb.Message.GetChat().Id, gotgbot.InputFileByReader(filename, strings.NewReader(formatDotenv(envs, masked))), &gotgbot.SendDocumentOpts{
+		Caption:   caption,
+		ParseMode: "HTML",
+	})
+	return err
+}
+
+func envImportHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	cb := ctx.CallbackQuery
+	if !ensureAllowed(b, ctx) {
+		return nil
+	}
+	_, _ = cb.Answer(b, nil)
+
+	appUUID := strings.TrimPrefix(cb.Data, "env_import:")
+	chatID := cb.Message.GetChat().Id
+	messageID := cb.Message.GetMessageId()
+
+	_, _, err := cb.Message.EditText(b, "📥 Upload a <code>.env</code> file as a document. You'll see a diff before anything changes.", &gotgbot.EditMessageTextOpts{
+		ParseMode: "HTML",
+		ReplyMarkup: gotgbot.InlineKeyboardMarkup{InlineKeyboard: [][]gotgbot.InlineKeyboardButton{
+			{{Text: "✖ Cancel", CallbackData: "app_envs:" + appUUID}},
+		}},
+	})
+	if err != nil {
+		return err
+	}
+
+	awaitInput(chatID, ctx.EffectiveUser.Id, envImportTTL, func(b *gotgbot.Bot, ctx *ext.Context) error {
+		text, markup := prepareEnvImport(b, ctx, appUUID)
+		_, _, err := b.EditMessageText(text, &gotgbot.EditMessageTextOpts{
+			ChatId:      chatID,
+			MessageId:   messageID,
+			ParseMode:   "HTML",
+			ReplyMarkup: markup,
+		})
+		return err
+	})
+	return nil
+}
+
+// prepareEnvImport downloads the uploaded document, diffs it against the app's
+// current variables and returns the preview with apply buttons.
+func prepareEnvImport(b *gotgbot.Bot, ctx *ext.Context, appUUID string) (string, gotgbot.InlineKeyboardMarkup) {
+	back := gotgbot.InlineKeyboardMarkup{InlineKeyboard: [][]gotgbot.InlineKeyboardButton{
+		{{Text: "🔙 Back to envs", CallbackData: "app_envs:" + appUUID}},
+	}}
+
+	msg := ctx.EffectiveMessage
+	if msg.Document == nil {
+		return "❌ Expected a .env document. Import cancelled.", back
+	}
+	if msg.Document.FileSize > maxEnvFileSize {
+		return "❌ File is too large for an env import.", back
+	}
+
+	data, err := downloadTelegramFile(b, msg.Document.FileId)
+	if err != nil {
+		return "❌ Failed to download file: " + html.EscapeString(errorText(err)), back
+	}
+	deleteSecretMessage(b, msg)
+
+	parsed, err := godotenv.Parse(bytes.NewReader(data))
+	if err != nil {
+		return "❌ Failed to parse file: " + html.EscapeString(errorText(err)), back
+	}
+	if err := checkNotMasked(data, parsed); err != nil {
+		return "❌ Refusing to import: " + html.EscapeString(err.Error()) + ".", back
+	}
+
+	current, err := config.Coolify.GetApplicationEnvsByUUIDWithContext(updateContext(ctx), appUUID)
+	if err != nil {
+		return "❌ Failed to fetch env vars: " + html.EscapeString(errorText(err)), back
+	}
+
+	existing := make(map[string]coolifyPkg.EnvironmentVariable)
+	for _, env := range runtimeEnvs(current) {
+		existing[env.Key] = env
+	}
+
+	keys := make([]string, 0, len(parsed))
+	for key := range parsed {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	plan := envImportPlan{appUUID: appUUID, userID: ctx.EffectiveUser.Id, expiresAt: time.Now().Add(envImportTTL)}
+	var added, changed, removed []string
+	for _, key := range keys {
+		value := parsed[key]
+		env, ok := existing[key]
+		switch {
+		case !ok:
+			added = append(added, key)
+			plan.upserts = append(plan.upserts, coolifyPkg.EnvironmentVariableInput{Key: key, Value: value, IsMultiline: strings.Contains(value, "\n")})
+		case env.Value != value:
+			changed = append(changed, key)
+			input := env.Input()
+			input.Value = value
+			plan.upserts = append(plan.upserts, input)
+		}
+	}
+	for _, env := range runtimeEnvs(current) {
+		if _, ok := parsed[env.Key]; !ok {
+			removed = append(removed, env.Key)
+			plan.removed = append(plan.removed, env)
+		}
+	}
+
+	if len(added)+len(changed)+len(removed) == 0 {
+		return "✅ The file matches the current variables. Nothing to do.", back
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<b>📥 Import preview</b>\n")
+	writeKeys := func(icon, label string, list []string) {
+		if len(list) == 0 {
+			return
+		}
+		sb.WriteString(fmt.Sprintf("\n%s <b>%s (%d)</b>\n", icon, label, len(list)))
+		for _, key := range list {
+			sb.WriteString("<code>" + html.EscapeString(key) + "</code>\n")
+		}
+	}
+	writeKeys("➕", "Added", added)
+	writeKeys("✏️", "Changed", changed)
+	writeKeys("➖", "Not in file", removed)
+
+	nonce := storeImportPlan(plan)
+	var rows [][]gotgbot.InlineKeyboardButton
+	if len(plan.upserts) > 0 {
+		rows = append(rows, []gotgbot.InlineKeyboardButton{{Text: "✅ Apply", CallbackData: "env_apply:" + nonce}})
+	}
+	if len(removed) > 0 {
+		rows = append(rows, []gotgbot.InlineKeyboardButton{{Text: "✅ Apply & delete missing", CallbackData: "env_apply:" + nonce + ":d"}})
+	}
+	rows = append(rows, []gotgbot.InlineKeyboardButton{{Text: "✖ Cancel", CallbackData: "app_envs:" + appUUID}})
+	return sb.String(), gotgbot.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+func downloadTelegramFile(b *gotgbot.Bot, fileID string) ([]byte, error) {
+	file, err := b.GetFile(fileID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.Get(file.URL(b, nil))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("bad status: %s", resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxEnvFileSize))
+}
+
+func envApplyHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	cb := ctx.CallbackQuery
+	if !ensureAllowed(b, ctx) {
+		return nil
+	}
+
+	parts := strings.Split(strings.TrimPrefix(cb.Data, "env_apply:"), ":")
+	plan, ok := takeImportPlan(parts[0], ctx.EffectiveUser.Id)
+	if !ok {
+		_, _ = cb.Answer(b, &gotgbot.AnswerCallbackQueryOpts{
+			Text:      "⌛ This import has expired. Please upload the file again.",
+			ShowAlert: true,
+		})
+		return nil
+	}
+	if !isAllowed(updateContext(ctx), plan.userID, "env_import", plan.appUUID) {
+		_, _ = cb.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: "🚫 You are not authorized.", ShowAlert: true})
+		return nil
+	}
+	_, _ = cb.Answer(b, nil)
+
+	deleteMissing := len(parts) > 1 && parts[1] == "d"
+	var (
+		sb       strings.Builder
+		firstErr error
+	)
+	if len(plan.upserts) > 0 {
+		if err := config.Coolify.BulkUpdateApplicationEnvsWithContext(updateContext(ctx), plan.appUUID, plan.upserts); err != nil {
+			firstErr = err
+			sb.WriteString("❌ Bulk update failed: " + html.EscapeString(errorText(err)) + "\n")
+		} else {
+			sb.WriteString(fmt.Sprintf("✅ Saved %d variable(s).\n", len(plan.upserts)))
+		}
+	}
+	if deleteMissing {
+		deleted := 0
+		for _, env := range plan.removed {
+			if err := config.Coolify.DeleteApplicationEnvWithContext(updateContext(ctx), plan.appUUID, env.UUID); err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				sb.WriteString(fmt.Sprintf("❌ Failed to delete <code>%s</code>: %s\n", html.EscapeString(env.Key), html.EscapeString(errorText(err))))
+				continue
+			}
+			deleted++
+		}
+		sb.WriteString(fmt.Sprintf("🗑 Deleted %d variable(s).\n", deleted))
+	}
+	recordAudit(b, ctx.EffectiveUser, "env_import", plan.appUUID, "", sb.String(), firstErr)
+	sb.WriteString("\nRedeploy for the changes to take effect.")
+
+	_, _, err := cb.Message.EditText(b, sb.String(), &gotgbot.EditMessageTextOpts{
+		ParseMode:   "HTML",
+		ReplyMarkup: envChangedMarkup(plan.appUUID),
+	})
+	return err
+}
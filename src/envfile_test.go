@@ -0,0 +1,78 @@
+package src
+
+import (
+	"strings"
+	"testing"
+
+	coolifyPkg "coolifymanager/src/coolity"
+
+	"github.com/joho/godotenv"
+)
+
+func TestFormatDotenvRoundTrip(t *testing.T) {
+	values := map[string]string{
+		"PLAIN":     "value",
+		"EMPTY":     "",
+		"REFERENCE": "$SERVICE_PASSWORD_DB",
+		"BRACED":    "${SERVICE_FQDN_APP}/path",
+		"QUOTES":    `he said "hi"`,
+		"SINGLE":    "it's $HOME",
+		"COMMENT":   "a #b",
+		"NEWLINES":  "line1\nline2\n",
+		"BACKSLASH": `C:\path\to\dir`,
+		"MIXED":     "p@ss $x \"q\" #1\n\\end",
+		"SINGLE_NL": "it's\nmultiline \"quoted\" \\ $X",
+		"SPACES":    "  padded  ",
+	}
+
+	var envs []coolifyPkg.EnvironmentVariable
+	for key, value := range values {
+		envs = append(envs, coolifyPkg.EnvironmentVariable{Key: key, Value: value})
+	}
+	doc := formatDotenv(envs, false)
+
+	parsed, err := godotenv.Parse(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("parse exported document: %v\n%s", err, doc)
+	}
+	for key, want := range values {
+		if got := parsed[key]; got != want {
+			t.Errorf("%s: got %q, want %q\nexported as: %s", key, got, want, quoteDotenv(want))
+		}
+	}
+}
+
+func TestMaskedExportIsNotImportable(t *testing.T) {
+	envs := []coolifyPkg.EnvironmentVariable{
+		{Key: "DB_PASSWORD", Value: "correct horse battery"},
+		{Key: "PIN", Value: "1234"},
+		{Key: "EMPTY", Value: ""},
+	}
+	doc := formatDotenv(envs, true)
+
+	parsed, err := godotenv.Parse(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("parse masked export: %v\n%s", err, doc)
+	}
+	if err := checkNotMasked([]byte(doc), parsed); err == nil {
+		t.Errorf("masked export accepted for import:\n%s", doc)
+	}
+
+	// Without the header the masked values themselves are still caught.
+	body := strings.TrimPrefix(doc, maskedExportHeader+"\n")
+	parsed, _ = godotenv.Parse(strings.NewReader(body))
+	for _, env := range envs {
+		if !maskedValue.MatchString(parsed[env.Key]) {
+			t.Errorf("%s: masked value %q not recognised", env.Key, parsed[env.Key])
+		}
+	}
+	if err := checkNotMasked([]byte(body), parsed); err == nil {
+		t.Errorf("masked values accepted for import:\n%s", body)
+	}
+
+	plain := formatDotenv(envs, false)
+	parsed, _ = godotenv.Parse(strings.NewReader(plain))
+	if err := checkNotMasked([]byte(plain), parsed); err != nil {
+		t.Errorf("plain export rejected: %v", err)
+	}
+}
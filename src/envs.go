@@ -0,0 +1,300 @@
+package src
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"coolifymanager/src/config"
+	coolifyPkg "coolifymanager/src/coolity"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+)
+
+const (
+	envPerPage  = 8
+	envInputTTL = 3 * time.Minute
+)
+
+var envFlags = map[string]string{
+	"b": "Build time",
+	"p": "Preview",
+	"l": "Literal",
+	"m": "Multiline",
+}
+
+// maskValue hides all but the first two characters of a secret.
+func maskValue(v string) string {
+	runes := []rune(v)
+	if len(runes) == 0 {
+		return "(empty)"
+	}
+	if len(runes) <= 6 {
+		return strings.Repeat("•", len(runes))
+	}
+	return string(runes[:2]) + strings.Repeat("•", 6) + fmt.Sprintf(" (%d chars)", len(runes))
+}
+
+func findEnv(reqCtx context.Context, appUUID, envUUID string) (*coolifyPkg.EnvironmentVariable, error) {
+	envs, err := config.Coolify.GetApplicationEnvsByUUIDWithContext(reqCtx, appUUID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range envs {
+		if envs[i].UUID == envUUID {
+			return &envs[i], nil
+		}
+	}
+	return nil, errors.New("environment variable not found")
+}
+
+// parseEnvAssignment splits KEY=VALUE, allowing the value to span multiple lines.
+func parseEnvAssignment(text string) (string, string, bool) {
+	key, value, ok := strings.Cut(strings.TrimSpace(text), "=")
+	key = strings.TrimSpace(key)
+	if !ok || key == "" || strings.ContainsAny(key, " \t\n") {
+		return "", "", false
+	}
+	return key, value, true
+}
+
+// deleteSecretMessage removes a user message that carried a secret value; failures are ignored
+// because the bot may lack delete rights in groups.
+func deleteSecretMessage(b *gotgbot.Bot, msg *gotgbot.Message) {
+	_, _ = b.DeleteMessage(msg.Chat.Id, msg.MessageId, nil)
+}
+
+func envChangedMarkup(appUUID string) gotgbot.InlineKeyboardMarkup {
+	return gotgbot.InlineKeyboardMarkup{InlineKeyboard: [][]gotgbot.InlineKeyboardButton{
+		{{Text: "🚀 Redeploy now", CallbackData: "deploy:" + appUUID}},
+		{{Text: "🔙 Back to envs", CallbackData: "app_envs:" + appUUID}},
+	}}
+}
+
+func renderEnvMenu(appUUID string, env *coolifyPkg.EnvironmentVariable) (string, gotgbot.InlineKeyboardMarkup) {
+	text := fmt.Sprintf(
+		"<b>🔑 %s</b>\nValue: <code>%s</code>\n\nBuild time: %t\nPreview: %t\nLiteral: %t\nMultiline: %t",
+		html.EscapeString(env.Key), html.EscapeString(maskValue(env.Value)),
+		env.IsBuildTime, env.IsPreview, env.IsLiteral, env.IsMultiline,
+	)
+
+	toggle := func(flag string, on bool) gotgbot.InlineKeyboardButton {
+		state := "off"
+		if on {
+			state = "on"
+		}
+		return gotgbot.InlineKeyboardButton{
+			Text:         fmt.Sprintf("%s: %s", envFlags[flag], state),
+			CallbackData: fmt.Sprintf("env_flag:%s:%s:%s", appUUID, env.UUID, flag),
+		}
+	}
+
+	btns := [][]gotgbot.InlineKeyboardButton{
+		{{Text: "✏️ Edit value", CallbackData: fmt.Sprintf("env_edit:%s:%s", appUUID, env.UUID)}},
+		{toggle("b", env.IsBuildTime), toggle("p", env.IsPreview)},
+		{toggle("l", env.IsLiteral), toggle("m", env.IsMultiline)},
+		{{Text: "🗑 Delete", CallbackData: fmt.Sprintf("env_del:%s:%s", appUUID, env.UUID)}},
+		{{Text: "🔙 Back", CallbackData: "app_envs:" + appUUID}},
+	}
+	return text, gotgbot.InlineKeyboardMarkup{InlineKeyboard: btns}
+}
+
+func envMenuHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	cb := ctx.CallbackQuery
+	if !ensureAllowed(b, ctx) {
+		return nil
+	}
+	_, _ = cb.Answer(b, nil)
+
+	parts := strings.Split(strings.TrimPrefix(cb.Data, "env_menu:"), ":")
+	if len(parts) < 2 {
+		return nil
+	}
+	env, err := findEnv(updateContext(ctx), parts[0], parts[1])
+	if err != nil {
+		_, _, err = cb.Message.EditText(b, "❌ Failed to fetch env vars: "+errorText(err), nil)
+		return err
+	}
+
+	text, markup := renderEnvMenu(parts[0], env)
+	_, _, err = cb.Message.EditText(b, text, &gotgbot.EditMessageTextOpts{
+		ParseMode:   "HTML",
+		ReplyMarkup: markup,
+	})
+	return err
+}
+
+func envFlagHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	cb := ctx.CallbackQuery
+	if !ensureAllowed(b, ctx) {
+		return nil
+	}
+	_, _ = cb.Answer(b, nil)
+
+	parts := strings.Split(strings.TrimPrefix(cb.Data, "env_flag:"), ":")
+	if len(parts) < 3 {
+		return nil
+	}
+	appUUID, envUUID, flag := parts[0], parts[1], parts[2]
+
+	env, err := findEnv(updateContext(ctx), appUUID, envUUID)
+	if err != nil {
+		_, _, err = cb.Message.EditText(b, "❌ Failed to fetch env vars: "+errorText(err), nil)
+		return err
+	}
+
+	input := env.Input()
+	switch flag {
+	case "b":
+		input.IsBuildTime = !input.IsBuildTime
+	case "p":
+		input.IsPreview = !input.IsPreview
+	case "l":
+		input.IsLiteral = !input.IsLiteral
+	case "m":
+		input.IsMultiline = !input.IsMultiline
+	default:
+		return nil
+	}
+
+	err = config.Coolify.UpdateApplicationEnvWithContext(updateContext(ctx), appUUID, input)
+	recordAudit(b, ctx.EffectiveUser, "env_flag", appUUID, env.Key, fmt.Sprintf("%s toggled", envFlags[flag]), err)
+	if err != nil {
+		_, _, err = cb.Message.EditText(b, "❌ Update failed: "+errorText(err), nil)
+		return err
+	}
+
+	env.IsBuildTime, env.IsPreview, env.IsLiteral, env.IsMultiline = input.IsBuildTime, input.IsPreview, input.IsLiteral, input.IsMultiline
+	text, markup := renderEnvMenu(appUUID, env)
+	markup.InlineKeyboard = append([][]gotgbot.InlineKeyboardButton{
+		{{Text: "🚀 Redeploy to apply", CallbackData: "deploy:" + appUUID}},
+	}, markup.InlineKeyboard...)
+	_, _, err = cb.Message.EditText(b, text, &gotgbot.EditMessageTextOpts{
+		ParseMode:   "HTML",
+		ReplyMarkup: markup,
+	})
+	return err
+}
+
+func envAddHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	cb := ctx.CallbackQuery
+	if !ensureAllowed(b, ctx) {
+		return nil
+	}
+	_, _ = cb.Answer(b, nil)
+
+	appUUID := strings.TrimPrefix(cb.Data, "env_add:")
+	chatID := cb.Message.GetChat().Id
+	messageID := cb.Message.GetMessageId()
+
+	prompt := "➕ Send the new variable as <code>KEY=value</code>.\nYour message is deleted after reading so the value doesn't stay in chat."
+	_, _, err := cb.Message.EditText(b, prompt, &gotgbot.EditMessageTextOpts{
+		ParseMode: "HTML",
+		ReplyMarkup: gotgbot.InlineKeyboardMarkup{InlineKeyboard: [][]gotgbot.InlineKeyboardButton{
+			{{Text: "✖ Cancel", CallbackData: "app_envs:" + appUUID}},
+		}},
+	})
+	if err != nil {
+		return err
+	}
+
+	awaitInput(chatID, ctx.EffectiveUser.Id, envInputTTL, func(b *gotgbot.Bot, ctx *ext.Context) error {
+		msg := ctx.EffectiveMessage
+		deleteSecretMessage(b, msg)
+
+		key, value, ok := parseEnvAssignment(msg.Text)
+		if !ok {
+			_, err := b.SendMessage(chatID, "❌ Expected KEY=value. Nothing was saved.", nil)
+			return err
+		}
+
+		input := coolifyPkg.EnvironmentVariableInput{Key: key, Value: value, IsMultiline: strings.Contains(value, "\n")}
+		text := fmt.Sprintf("✅ Added <code>%s</code> = <code>%s</code>\nRedeploy for the change to take effect.", html.EscapeString(key), html.EscapeString(maskValue(value)))
+		_, err := config.Coolify.CreateApplicationEnvWithContext(updateContext(ctx), appUUID, input)
+		recordAudit(b, ctx.EffectiveUser, "env_add", appUUID, key, "", err)
+		if err != nil {
+			text = "❌ Create failed: " + html.EscapeString(errorText(err))
+		}
+		_, _, err = b.EditMessageText(text, &gotgbot.EditMessageTextOpts{
+			ChatId:      chatID,
+			MessageId:   messageID,
+			ParseMode:   "HTML",
+			ReplyMarkup: envChangedMarkup(appUUID),
+		})
+		return err
+	})
+	return nil
+}
+
+func envEditHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	cb := ctx.CallbackQuery
+	if !ensureAllowed(b, ctx) {
+		return nil
+	}
+	_, _ = cb.Answer(b, nil)
+
+	parts := strings.Split(strings.TrimPrefix(cb.Data, "env_edit:"), ":")
+	if len(parts) < 2 {
+		return nil
+	}
+	appUUID, envUUID := parts[0], parts[1]
+
+	env, err := findEnv(updateContext(ctx), appUUID, envUUID)
+	if err != nil {
+		_, _, err = cb.Message.EditText(b, "❌ Failed to fetch env vars: "+errorText(err), nil)
+		return err
+	}
+
+	chatID := cb.Message.GetChat().Id
+	messageID := cb.Message.GetMessageId()
+	prompt := fmt.Sprintf("✏️ Send the new value for <code>%s</code>.\nYour message is deleted after reading.", html.EscapeString(env.Key))
+	_, _, err = cb.Message.EditText(b, prompt, &gotgbot.EditMessageTextOpts{
+		ParseMode: "HTML",
+		ReplyMarkup: gotgbot.InlineKeyboardMarkup{InlineKeyboard: [][]gotgbot.InlineKeyboardButton{
+			{{Text: "✖ Cancel", CallbackData: fmt.Sprintf("env_menu:%s:%s", appUUID, envUUID)}},
+		}},
+	})
+	if err != nil {
+		return err
+	}
+
+	awaitInput(chatID, ctx.EffectiveUser.Id, envInputTTL, func(b *gotgbot.Bot, ctx *ext.Context) error {
+		msg := ctx.EffectiveMessage
+		deleteSecretMessage(b, msg)
+
+		input := env.Input()
+		input.Value = msg.Text
+		if strings.Contains(msg.Text, "\n") {
+			input.IsMultiline = true
+		}
+
+		text := fmt.Sprintf("✅ Updated <code>%s</code> = <code>%s</code>\nRedeploy for the change to take effect.", html.EscapeString(env.Key), html.EscapeString(maskValue(msg.Text)))
+		err := config.Coolify.UpdateApplicationEnvWithContext(updateContext(ctx), appUUID, input)
+		recordAudit(b, ctx.EffectiveUser, "env_edit", appUUID, env.Key, "", err)
+		if err != nil {
+			text = "❌ Update failed: " + html.EscapeString(errorText(err))
+		}
+		_, _, err = b.EditMessageText(text, &gotgbot.EditMessageTextOpts{
+			ChatId:      chatID,
+			MessageId:   messageID,
+			ParseMode:   "HTML",
+			ReplyMarkup: envChangedMarkup(appUUID),
+		})
+		return err
+	})
+	return nil
+}
+
+func envDeleteHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	cb := ctx.CallbackQuery
+	if !ensureAllowed(b, ctx) {
+		return nil
+	}
+	_, _ = cb.Answer(b, nil)
+
+	return askConfirmation(b, ctx, "env_delete", strings.TrimPrefix(cb.Data, "env_del:"))
+}
@@ -0,0 +1,77 @@
+package src
+
+import (
+	"sync"
+	"time"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+)
+
+// inputHandler consumes the next message a user sends in a chat.
+type inputHandler func(b *gotgbot.Bot, ctx *ext.Context) error
+
+type inputKey struct {
+	chatID int64
+	userID int64
+}
+
+type pendingInput struct {
+	handler   inputHandler
+	expiresAt time.Time
+}
+
+var (
+	inputMu       sync.Mutex
+	pendingInputs = make(map[inputKey]pendingInput)
+)
+
+// awaitInput routes the next non-command message from userID in chatID to h.
+// A newer call for the same chat and user replaces the previous one.
+func awaitInput(chatID, userID int64, ttl time.Duration, h inputHandler) {
+	inputMu.Lock()
+	defer inputMu.Unlock()
+	pendingInputs[inputKey{chatID, userID}] = pendingInput{handler: h, expiresAt: time.Now().Add(ttl)}
+}
+
+func cancelInput(chatID, userID int64) {
+	inputMu.Lock()
+	defer inputMu.Unlock()
+	delete(pendingInputs, inputKey{chatID, userID})
+}
+
+// takeInput removes and returns the pending handler, if one exists and has not expired.
+func takeInput(chatID, userID int64) (inputHandler, bool) {
+	inputMu.Lock()
+	defer inputMu.Unlock()
+
+	key := inputKey{chatID, userID}
+	pending, ok := pendingInputs[key]
+	if !ok {
+		return nil, false
+	}
+	delete(pendingInputs, key)
+	if time.Now().After(pending.expiresAt) {
+		return nil, false
+	}
+	return pending.handler, true
+}
+
+func hasPendingInput(msg *gotgbot.Message) bool {
+	if msg.From == nil || (msg.Text != "" && msg.Text[0] == '/') {
+		return false
+	}
+
+	inputMu.Lock()
+	defer inputMu.Unlock()
+	pending, ok := pendingInputs[inputKey{msg.Chat.Id, msg.From.Id}]
+	return ok && time.Now().Before(pending.expiresAt)
+}
+
+func pendingInputMessageHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	h, ok := takeInput(ctx.EffectiveChat.Id, ctx.EffectiveUser.Id)
+	if !ok {
+		return nil
+	}
+	return h(b, ctx)
+}
@@ -1,13 +1,16 @@
 package src
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"html"
 	"log"
 	"time"
 
 	"coolifymanager/src/config"
+	coolifyPkg "coolifymanager/src/coolity"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/PaulSonOfLars/gotgbot/v2/ext"
@@ -50,6 +53,57 @@ func errorHandler(bot *gotgbot.Bot, ctx *ext.Context, err error) ext.DispatcherA
 	return ext.DispatcherActionNoop
 }
 
+// errorText is the error shown to users. Coolify API errors get a short
+// explanation of what went wrong instead of the request details.
+func errorText(err error) string {
+	var apiErr *coolifyPkg.APIError
+	if !errors.As(err, &apiErr) {
+		return err.Error()
+	}
+
+	switch {
+	case coolifyPkg.IsUnauthorized(err):
+		return "Coolify rejected the API token. Check API_TOKEN and its permissions."
+	case coolifyPkg.IsNotFound(err):
+		return "Not found in Coolify. It may have been deleted."
+	case coolifyPkg.IsRateLimited(err):
+		return "Coolify is rate limiting requests. Try again in a moment."
+	case coolifyPkg.IsValidation(err):
+		if details := apiErr.ValidationDetails(); details != "" {
+			return "Coolify rejected the input: " + details
+		}
+		return "Coolify rejected the input: " + apiErr.Message
+	}
+	if apiErr.Message != "" {
+		return fmt.Sprintf("%s (HTTP %d)", apiErr.Message, apiErr.StatusCode)
+	}
+	return fmt.Sprintf("Coolify answered with HTTP %d.", apiErr.StatusCode)
+}
+
+// updateTimeout bounds the Coolify calls made while handling one update.
+const updateTimeout = 2 * time.Minute
+
+const updateContextKey = "coolify_ctx"
+
+// withUpdateContext gives every update its own context, cancelled when h
+// returns or the bot shuts down. Handlers read it with updateContext.
+func withUpdateContext(h handlers.Response) handlers.Response {
+	return func(b *gotgbot.Bot, ctx *ext.Context) error {
+		reqCtx, cancel := context.WithTimeout(config.Context(), updateTimeout)
+		defer cancel()
+		ctx.Data[updateContextKey] = reqCtx
+		return h(b, ctx)
+	}
+}
+
+// updateContext returns the context of the update being handled.
+func updateContext(ctx *ext.Context) context.Context {
+	if reqCtx, ok := ctx.Data[updateContextKey].(context.Context); ok {
+		return reqCtx
+	}
+	return config.Context()
+}
+
 var (
 	startTime  = time.Now()
 	Dispatcher = newDispatcher()
@@ -57,21 +111,76 @@ var (
 
 func newDispatcher() *ext.Dispatcher {
 	dispatcher := ext.NewDispatcher(&ext.DispatcherOpts{Error: errorHandler, MaxRoutines: -1})
-	dispatcher.AddHandler(handlers.NewCommand("start", startHandler))
-	dispatcher.AddHandler(handlers.NewCommand("ping", pingCommandHandler))
-
-	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("list_projects"), listProjectsHandler))
-	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("list_deployments"), listDeploymentsHandler))
-	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("list_environments"), listEnvironmentsHandler))
-	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("list_databases"), listDatabasesHandler))
-	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("project_menu:"), projectMenuHandler))
-	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("app_deployments:"), projectDeploymentsHandler))
-	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("app_envs:"), appEnvsHandler))
-	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("restart:"), restartHandler))
-	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("deploy:"), deployHandler))
-	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("logs:"), logsHandler))
-	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("status:"), statusHandler))
-	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("stop:"), stopHandler))
-	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("delete:"), deleteHandler))
+	dispatcher.AddHandler(handlers.NewCommand("start", withUpdateContext(startHandler)))
+	dispatcher.AddHandler(handlers.NewCommand("ping", withUpdateContext(pingCommandHandler)))
+	dispatcher.AddHandler(handlers.NewCommand("version", withUpdateContext(versionCommandHandler)))
+	dispatcher.AddHandler(handlers.NewCommand("apps", withUpdateContext(appsCommandHandler)))
+	dispatcher.AddHandler(handlers.NewCommand("deploy", withUpdateContext(deployCommandHandler)))
+	dispatcher.AddHandler(handlers.NewCommand("restart", withUpdateContext(restartCommandHandler)))
+	dispatcher.AddHandler(handlers.NewCommand("stop", withUpdateContext(stopCommandHandler)))
+	dispatcher.AddHandler(handlers.NewCommand("logs", withUpdateContext(logsCommandHandler)))
+	dispatcher.AddHandler(handlers.NewCommand("status", withUpdateContext(statusCommandHandler)))
+	dispatcher.AddHandler(handlers.NewCommand("envs", withUpdateContext(envsCommandHandler)))
+	dispatcher.AddHandler(handlers.NewCommand("newapp", withUpdateContext(newAppCommandHandler)))
+	dispatcher.AddHandler(handlers.NewCommand("schedule", withUpdateContext(scheduleCommandHandler)))
+	dispatcher.AddHandler(handlers.NewCommand("subscribe", withUpdateContext(subscribeCommandHandler)))
+	dispatcher.AddHandler(handlers.NewCommand("unsubscribe", withUpdateContext(unsubscribeCommandHandler)))
+	dispatcher.AddHandler(handlers.NewCommand("grant", withUpdateContext(grantCommandHandler)))
+	dispatcher.AddHandler(handlers.NewCommand("revoke", withUpdateContext(revokeCommandHandler)))
+	dispatcher.AddHandler(handlers.NewCommand("roles", withUpdateContext(rolesCommandHandler)))
+	dispatcher.AddHandler(handlers.NewCommand("audit", withUpdateContext(auditCommandHandler)))
+	dispatcher.AddHandler(handlers.NewCommand("cache", withUpdateContext(cacheCommandHandler)))
+
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("list_projects"), withUpdateContext(listProjectsHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("list_deployments"), withUpdateContext(listDeploymentsHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("list_environments"), withUpdateContext(listEnvironmentsHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("list_databases"), withUpdateContext(listDatabasesHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("db_menu:"), withUpdateContext(databaseMenuHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("db_start:"), withUpdateContext(databaseLifecycleHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("db_restart:"), withUpdateContext(databaseLifecycleHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("db_backups:"), withUpdateContext(dbBackupsHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("db_bkp_now:"), withUpdateContext(dbBackupNowHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("db_stop:"), withUpdateContext(databaseStopHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("db_del:"), withUpdateContext(databaseDeleteHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("list_services"), withUpdateContext(listServicesHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("na:"), withUpdateContext(newAppCallbackHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("browse:"), withUpdateContext(browseHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("browse_proj:"), withUpdateContext(browseProjectHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("browse_env:"), withUpdateContext(browseEnvironmentHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("list_servers"), withUpdateContext(listServersHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("server_menu:"), withUpdateContext(serverMenuHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("srv_validate:"), withUpdateContext(serverValidateHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("version_probe"), withUpdateContext(versionProbeHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("cache_"), withUpdateContext(cacheStatsHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("service_menu:"), withUpdateContext(serviceMenuHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("svc_start:"), withUpdateContext(serviceLifecycleHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("svc_restart:"), withUpdateContext(serviceLifecycleHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("svc_stop:"), withUpdateContext(serviceStopHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("svc_envs:"), withUpdateContext(serviceEnvsHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("project_menu:"), withUpdateContext(projectMenuHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("app_deployments:"), withUpdateContext(projectDeploymentsHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("app_envs:"), withUpdateContext(appEnvsHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("env_menu:"), withUpdateContext(envMenuHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("env_add:"), withUpdateContext(envAddHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("env_edit:"), withUpdateContext(envEditHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("env_flag:"), withUpdateContext(envFlagHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("env_del:"), withUpdateContext(envDeleteHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("env_export:"), withUpdateContext(envExportHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("env_import:"), withUpdateContext(envImportHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("env_apply:"), withUpdateContext(envApplyHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("dep_cancel:"), withUpdateContext(cancelDeploymentHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("rollback:"), withUpdateContext(rollbackHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("unpin:"), withUpdateContext(unpinHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("restart:"), withUpdateContext(restartHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("deploy:"), withUpdateContext(deployHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("logs:"), withUpdateContext(logsHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("status:"), withUpdateContext(statusHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("stop:"), withUpdateContext(stopHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("delete:"), withUpdateContext(deleteHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("app_settings:"), withUpdateContext(appSettingsHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("set:"), withUpdateContext(setSettingHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("set_save:"), withUpdateContext(saveSettingHandler)))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("confirm:"), withUpdateContext(confirmHandler)))
+	dispatcher.AddHandler(handlers.NewMessage(hasPendingInput, withUpdateContext(pendingInputMessageHandler)))
 	return dispatcher
 }
@@ -0,0 +1,494 @@
+package src
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"strings"
+	"sync"
+	"time"
+
+	"coolifymanager/src/config"
+	coolifyPkg "coolifymanager/src/coolity"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+)
+
+const wizardTTL = 15 * time.Minute
+
+var appSources = []struct{ key, label string }{
+	{"public", "🌐 Public repository"},
+	{"ghapp", "🔐 Private repo (GitHub App)"},
+	{"key", "🔑 Private repo (deploy key)"},
+	{"dockerfile", "🐳 Dockerfile"},
+	{"image", "📦 Docker image"},
+	{"compose", "🧱 Docker Compose"},
+}
+
+var buildPacks = []string{"nixpacks", "static", "dockerfile", "dockercompose"}
+
+// appWizard collects the answers of a /newapp conversation. It lives in one
+// message that is edited after every step.
+type appWizard struct {
+	chatID    int64
+	messageID int64
+	source    string
+	req       coolifyPkg.CreateApplicationRequest
+	summary   []string
+	expiresAt time.Time
+}
+
+var (
+	wizardMu sync.Mutex
+	wizards  = make(map[int64]*appWizard)
+)
+
+func startWizard(userID int64, w *appWizard) {
+	wizardMu.Lock()
+	defer wizardMu.Unlock()
+	w.expiresAt = time.Now().Add(wizardTTL)
+	wizards[userID] = w
+}
+
+func getWizard(userID, chatID int64) *appWizard {
+	wizardMu.Lock()
+	defer wizardMu.Unlock()
+
+	w, ok := wizards[userID]
+	if !ok || w.chatID != chatID || time.Now().After(w.expiresAt) {
+		return nil
+	}
+	w.expiresAt = time.Now().Add(wizardTTL)
+	return w
+}
+
+func endWizard(userID int64) {
+	wizardMu.Lock()
+	defer wizardMu.Unlock()
+	delete(wizards, userID)
+}
+
+// show replaces the wizard message with a step, adding the answers so far and a cancel button.
+func (w *appWizard) show(b *gotgbot.Bot, prompt string, rows [][]gotgbot.InlineKeyboardButton) error {
+	var sb strings.Builder
+	sb.WriteString("<b>🆕 New application</b>\n")
+	for _, line := range w.summary {
+		sb.WriteString(line + "\n")
+	}
+	sb.WriteString("\n" + prompt)
+
+	rows = append(rows, []gotgbot.InlineKeyboardButton{{Text: "✖ Cancel", CallbackData: "na:cancel"}})
+	_, _, err := b.EditMessageText(sb.String(), &gotgbot.EditMessageTextOpts{
+		ChatId:             w.chatID,
+		MessageId:          w.messageID,
+		ParseMode:          "HTML",
+		ReplyMarkup:        gotgbot.InlineKeyboardMarkup{InlineKeyboard: rows},
+		LinkPreviewOptions: &gotgbot.LinkPreviewOptions{IsDisabled: true},
+	})
+	return err
+}
+
+func (w *appWizard) record(label, value string) {
+	w.summary = append(w.summary, fmt.Sprintf("%s: <code>%s</code>", label, html.EscapeString(truncateText(value, 80))))
+}
+
+// ask shows prompt and passes the user's next text message to next.
+func (w *appWizard) ask(b *gotgbot.Bot, userID int64, prompt string, next func(reqCtx context.Context, b *gotgbot.Bot, text string) error) error {
+	awaitInput(w.chatID, userID, wizardTTL, func(b *gotgbot.Bot, ctx *ext.Context) error {
+		if getWizard(userID, w.chatID) != w {
+			return nil
+		}
+		text := strings.TrimSpace(ctx.EffectiveMessage.Text)
+		if text == "" {
+			return w.ask(b, userID, "❌ Please send text.\n\n"+prompt, next)
+		}
+		return next(updateContext(ctx), b, text)
+	})
+	return w.show(b, prompt, nil)
+}
+
+func newAppCommandHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	if !ensureAdminCommand(b, ctx) {
+		return ext.EndGroups
+	}
+
+	projects, err := config.Coolify.ListProjectsWithContext(updateContext(ctx))
+	if err != nil {
+		_, err = ctx.EffectiveMessage.Reply(b, "❌ Failed to fetch projects: "+errorText(err), nil)
+		return err
+	}
+	if len(projects) == 0 {
+		_, err = ctx.EffectiveMessage.Reply(b, "😶 No projects found. Create one in Coolify first.", nil)
+		return err
+	}
+
+	msg, err := replyHTML(b, ctx.EffectiveMessage, "<b>🆕 New application</b>", nil)
+	if err != nil {
+		return err
+	}
+	w := &appWizard{chatID: msg.Chat.Id, messageID: msg.MessageId}
+	startWizard(ctx.EffectiveUser.Id, w)
+
+	var rows [][]gotgbot.InlineKeyboardButton
+	for _, p := range projects {
+		rows = append(rows, []gotgbot.InlineKeyboardButton{{Text: "🗂 " + p.Name, CallbackData: "na:proj:" + p.UUID}})
+	}
+	return w.show(b, "Select the project:", rows)
+}
+
+// newAppCallbackHandler drives the button steps of the wizard: "na:<step>[:<value>]".
+func newAppCallbackHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	cb := ctx.CallbackQuery
+	if !ensureAllowed(b, ctx) {
+		return nil
+	}
+
+	userID := ctx.EffectiveUser.Id
+	w := getWizard(userID, cb.Message.GetChat().Id)
+	if w == nil || w.messageID != cb.Message.GetMessageId() {
+		_, _ = cb.Answer(b, &gotgbot.AnswerCallbackQueryOpts{
+			Text:      "⌛ This wizard has expired. Start again with /newapp.",
+			ShowAlert: true,
+		})
+		return nil
+	}
+	_, _ = cb.Answer(b, nil)
+
+	reqCtx := updateContext(ctx)
+	parts := strings.SplitN(strings.TrimPrefix(cb.Data, "na:"), ":", 2)
+	step, value := parts[0], ""
+	if len(parts) > 1 {
+		value = parts[1]
+	}
+
+	switch step {
+	case "cancel":
+		endWizard(userID)
+		cancelInput(w.chatID, userID)
+		_, _, err := cb.Message.EditText(b, "✖ Application creation cancelled.", nil)
+		return err
+	case "proj":
+		return wizardProject(reqCtx, b, w, value)
+	case "env":
+		return wizardEnvironment(reqCtx, b, w, value)
+	case "srv":
+		return wizardServer(reqCtx, b, w, value)
+	case "src":
+		return wizardSource(reqCtx, b, w, userID, value)
+	case "gh":
+		return wizardGitHubApp(reqCtx, b, w, userID, value)
+	case "key":
+		return wizardPrivateKey(reqCtx, b, w, userID, value)
+	case "bp":
+		w.req.BuildPack = value
+		w.record("Build pack", value)
+		if value == "dockercompose" {
+			return wizardName(b, w, userID)
+		}
+		return wizardDomain(b, w, userID)
+	case "go":
+		endWizard(userID)
+		cancelInput(w.chatID, userID)
+		w.req.InstantDeploy = value == "1"
+		return wizardCreate(reqCtx, b, ctx.EffectiveUser, w)
+	}
+	return nil
+}
+
+func wizardProject(reqCtx context.Context, b *gotgbot.Bot, w *appWizard, uuid string) error {
+	project, err := config.Coolify.GetProjectWithContext(reqCtx, uuid)
+	if err != nil {
+		return w.show(b, "❌ Failed to load project: "+html.EscapeString(errorText(err)), nil)
+	}
+	w.req.ProjectUUID = project.UUID
+	w.record("Project", project.Name)
+
+	if len(project.Environments) == 0 {
+		return w.show(b, "❌ This project has no environments. Create one in Coolify first.", nil)
+	}
+	var rows [][]gotgbot.InlineKeyboardButton
+	for _, env := range project.Environments {
+		rows = append(rows, []gotgbot.InlineKeyboardButton{{Text: "🌍 " + env.Name, CallbackData: "na:env:" + environmentKey(env)}})
+	}
+	return w.show(b, "Select the environment:", rows)
+}
+
+func wizardEnvironment(reqCtx context.Context, b *gotgbot.Bot, w *appWizard, key string) error {
+	project, err := config.Coolify.GetProjectWithContext(reqCtx, w.req.ProjectUUID)
+	if err != nil {
+		return w.show(b, "❌ Failed to load project: "+html.EscapeString(errorText(err)), nil)
+	}
+	env, ok := findEnvironment(project, key)
+	if !ok {
+		return w.show(b, "❌ Environment not found.", nil)
+	}
+	if env.UUID != "" {
+		w.req.EnvironmentUUID = env.UUID
+	} else {
+		w.req.EnvironmentName = env.Name
+	}
+	w.record("Environment", env.Name)
+
+	servers, err := config.Coolify.ListServersWithContext(reqCtx)
+	if err != nil {
+		return w.show(b, "❌ Failed to fetch servers: "+html.EscapeString(errorText(err)), nil)
+	}
+	var rows [][]gotgbot.InlineKeyboardButton
+	for _, s := range servers {
+		rows = append(rows, []gotgbot.InlineKeyboardButton{
+			{Text: fmt.Sprintf("%s %s (%s)", serverStateIcon(s), s.Name, s.IP), CallbackData: "na:srv:" + s.UUID},
+		})
+	}
+	return w.show(b, "Select the server:", rows)
+}
+
+func wizardServer(reqCtx context.Context, b *gotgbot.Bot, w *appWizard, uuid string) error {
+	server, err := config.Coolify.GetServerWithContext(reqCtx, uuid)
+	if err != nil {
+		return w.show(b, "❌ Failed to load server: "+html.EscapeString(errorText(err)), nil)
+	}
+	w.req.ServerUUID = server.UUID
+	w.record("Server", server.Name)
+
+	var rows [][]gotgbot.InlineKeyboardButton
+	for _, s := range appSources {
+		rows = append(rows, []gotgbot.InlineKeyboardButton{{Text: s.label, CallbackData: "na:src:" + s.key}})
+	}
+	return w.show(b, "Where does the application come from?", rows)
+}
+
+func wizardSource(reqCtx context.Context, b *gotgbot.Bot, w *appWizard, userID int64, source string) error {
+	w.source = source
+	for _, s := range appSources {
+		if s.key == source {
+			w.record("Source", s.label)
+		}
+	}
+
+	switch source {
+	case "public":
+		return wizardRepository(b, w, userID)
+	case "ghapp":
+		apps, err := config.Coolify.ListGitHubAppsWithContext(reqCtx)
+		if err != nil {
+			return w.show(b, "❌ Failed to fetch GitHub Apps: "+html.EscapeString(errorText(err)), nil)
+		}
+		var rows [][]gotgbot.InlineKeyboardButton
+		for _, app := range apps {
+			rows = append(rows, []gotgbot.InlineKeyboardButton{{Text: "🐙 " + app.Name, CallbackData: "na:gh:" + app.UUID}})
+		}
+		if len(rows) == 0 {
+			return w.show(b, "❌ No GitHub Apps are connected to Coolify.", nil)
+		}
+		return w.show(b, "Select the GitHub App:", rows)
+	case "key":
+		keys, err := config.Coolify.ListPrivateKeysWithContext(reqCtx)
+		if err != nil {
+			return w.show(b, "❌ Failed to fetch private keys: "+html.EscapeString(errorText(err)), nil)
+		}
+		var rows [][]gotgbot.InlineKeyboardButton
+		for _, key := range keys {
+			rows = append(rows, []gotgbot.InlineKeyboardButton{{Text: "🔑 " + key.Name, CallbackData: "na:key:" + key.UUID}})
+		}
+		if len(rows) == 0 {
+			return w.show(b, "❌ No private keys are stored in Coolify.", nil)
+		}
+		return w.show(b, "Select the deploy key:", rows)
+	case "dockerfile":
+		return w.ask(b, userID, "Send the Dockerfile content:", func(reqCtx context.Context, b *gotgbot.Bot, text string) error {
+			w.req.Dockerfile = text
+			w.record("Dockerfile", fmt.Sprintf("%d lines", strings.Count(text, "\n")+1))
+			return wizardDomain(b, w, userID)
+		})
+	case "image":
+		return w.ask(b, userID, "Send the image as <code>name[:tag]</code>, e.g. <code>ghcr.io/org/app:1.2</code>:", func(reqCtx context.Context, b *gotgbot.Bot, text string) error {
+			w.req.DockerRegistryImageName, w.req.DockerRegistryImageTag = splitImage(text)
+			w.record("Image", w.req.DockerRegistryImageName+":"+w.req.DockerRegistryImageTag)
+			return wizardDomain(b, w, userID)
+		})
+	case "compose":
+		return w.ask(b, userID, "Send the docker-compose.yml content:", func(reqCtx context.Context, b *gotgbot.Bot, text string) error {
+			w.req.DockerComposeRaw = text
+			w.record("Compose", fmt.Sprintf("%d lines", strings.Count(text, "\n")+1))
+			return wizardName(b, w, userID)
+		})
+	}
+	return nil
+}
+
+func wizardGitHubApp(reqCtx context.Context, b *gotgbot.Bot, w *appWizard, userID int64, uuid string) error {
+	apps, err := config.Coolify.ListGitHubAppsWithContext(reqCtx)
+	if err != nil {
+		return w.show(b, "❌ Failed to fetch GitHub Apps: "+html.EscapeString(errorText(err)), nil)
+	}
+	for _, app := range apps {
+		if app.UUID == uuid {
+			w.req.GitHubAppUUID = app.UUID
+			w.record("GitHub App", app.Name)
+			return wizardRepository(b, w, userID)
+		}
+	}
+	return w.show(b, "❌ GitHub App not found.", nil)
+}
+
+func wizardPrivateKey(reqCtx context.Context, b *gotgbot.Bot, w *appWizard, userID int64, uuid string) error {
+	keys, err := config.Coolify.ListPrivateKeysWithContext(reqCtx)
+	if err != nil {
+		return w.show(b, "❌ Failed to fetch private keys: "+html.EscapeString(errorText(err)), nil)
+	}
+	for _, key := range keys {
+		if key.UUID == uuid {
+			w.req.PrivateKeyUUID = key.UUID
+			w.record("Deploy key", key.Name)
+			return wizardRepository(b, w, userID)
+		}
+	}
+	return w.show(b, "❌ Private key not found.", nil)
+}
+
+// splitImage separates the tag from an image reference, ignoring a registry port.
+func splitImage(ref string) (string, string) {
+	slash := strings.LastIndex(ref, "/")
+	if colon := strings.LastIndex(ref, ":"); colon > slash {
+		return ref[:colon], ref[colon+1:]
+	}
+	return ref, "latest"
+}
+
+func wizardRepository(b *gotgbot.Bot, w *appWizard, userID int64) error {
+	prompt := "Send the repository URL, e.g. <code>https://github.com/org/repo</code>:"
+	if w.source == "key" {
+		prompt = "Send the SSH repository URL, e.g. <code>git@github.com:org/repo.git</code>:"
+	}
+	return w.ask(b, userID, prompt, func(reqCtx context.Context, b *gotgbot.Bot, text string) error {
+		w.req.GitRepository = text
+		w.record("Repository", text)
+		return w.ask(b, userID, "Send the branch, or <code>-</code> for <code>main</code>:", func(reqCtx context.Context, b *gotgbot.Bot, text string) error {
+			if text == "-" {
+				text = "main"
+			}
+			w.req.GitBranch = text
+			w.record("Branch", text)
+
+			var row []gotgbot.InlineKeyboardButton
+			for _, bp := range buildPacks {
+				row = append(row, gotgbot.InlineKeyboardButton{Text: bp, CallbackData: "na:bp:" + bp})
+			}
+			return w.show(b, "Select the build pack:", [][]gotgbot.InlineKeyboardButton{row[:2], row[2:]})
+		})
+	})
+}
+
+func wizardDomain(b *gotgbot.Bot, w *appWizard, userID int64) error {
+	prompt := "Send the domain(s), comma separated, e.g. <code>https://app.example.com</code>, or <code>-</code> to let Coolify generate one:"
+	return w.ask(b, userID, prompt, func(reqCtx context.Context, b *gotgbot.Bot, text string) error {
+		if text != "-" {
+			w.req.Domains = text
+			w.record("Domains", text)
+		}
+		return wizardPorts(b, w, userID)
+	})
+}
+
+func wizardPorts(b *gotgbot.Bot, w *appWizard, userID int64) error {
+	fallback := "3000"
+	if w.req.BuildPack == "static" {
+		fallback = "80"
+	}
+	prompt := fmt.Sprintf("Send the exposed port(s), comma separated, or <code>-</code> for <code>%s</code>:", fallback)
+	return w.ask(b, userID, prompt, func(reqCtx context.Context, b *gotgbot.Bot, text string) error {
+		if text == "-" {
+			text = fallback
+		}
+		w.req.PortsExposes = text
+		w.record("Ports", text)
+		return wizardName(b, w, userID)
+	})
+}
+
+// wizardName asks for the application name, offering one derived from the
+// repository or image when there is one.
+func wizardName(b *gotgbot.Bot, w *appWizard, userID int64) error {
+	fallback := defaultAppName(w)
+	prompt := "Send the application name:"
+	if fallback != "" {
+		prompt = fmt.Sprintf("Send the application name, or <code>-</code> for <code>%s</code>:", html.EscapeString(fallback))
+	}
+	return w.ask(b, userID, prompt, func(reqCtx context.Context, b *gotgbot.Bot, text string) error {
+		if text == "-" && fallback != "" {
+			text = fallback
+		}
+		w.req.Name = text
+		w.record("Name", text)
+		return wizardSummary(b, w)
+	})
+}
+
+// defaultAppName returns the last path segment of the repository or image,
+// e.g. "repo" for "git@github.com:org/repo.git".
+func defaultAppName(w *appWizard) string {
+	ref := w.req.GitRepository
+	if ref == "" {
+		ref = w.req.DockerRegistryImageName
+	}
+	ref = strings.TrimSuffix(strings.TrimRight(ref, "/"), ".git")
+	if i := strings.LastIndexAny(ref, "/:"); i >= 0 {
+		ref = ref[i+1:]
+	}
+	return ref
+}
+
+func wizardSummary(b *gotgbot.Bot, w *appWizard) error {
+	rows := [][]gotgbot.InlineKeyboardButton{
+		{{Text: "🚀 Create & deploy", CallbackData: "na:go:1"}},
+		{{Text: "✅ Create only", CallbackData: "na:go:0"}},
+	}
+	return w.show(b, "Ready. Create the application?", rows)
+}
+
+func wizardCreate(reqCtx context.Context, b *gotgbot.Bot, user *gotgbot.User, w *appWizard) error {
+	var (
+		res *coolifyPkg.CreateResourceResponse
+		err error
+	)
+	switch w.source {
+	case "public":
+		res, err = config.Coolify.CreatePublicApplicationWithContext(reqCtx, w.req)
+	case "ghapp":
+		res, err = config.Coolify.CreatePrivateGitHubAppApplicationWithContext(reqCtx, w.req)
+	case "key":
+		res, err = config.Coolify.CreatePrivateDeployKeyApplicationWithContext(reqCtx, w.req)
+	case "dockerfile":
+		res, err = config.Coolify.CreateDockerfileApplicationWithContext(reqCtx, w.req)
+	case "image":
+		res, err = config.Coolify.CreateDockerImageApplicationWithContext(reqCtx, w.req)
+	case "compose":
+		res, err = config.Coolify.CreateDockerComposeApplicationWithContext(reqCtx, w.req)
+	default:
+		err = errors.New("unknown source")
+	}
+
+	target := ""
+	if err == nil {
+		target = res.UUID
+	}
+	recordAudit(b, user, "create_app", target, w.req.Name, w.source+" application in project "+w.req.ProjectUUID, err)
+
+	opts := &gotgbot.EditMessageTextOpts{ChatId: w.chatID, MessageId: w.messageID, ParseMode: "HTML"}
+	if err != nil {
+		_, _, err = b.EditMessageText("❌ Create failed: "+html.EscapeString(errorText(err)), opts)
+		return err
+	}
+
+	text := fmt.Sprintf("✅ Application created!\nUUID: <code>%s</code>", res.UUID)
+	rows := [][]gotgbot.InlineKeyboardButton{{{Text: "📦 Open", CallbackData: "project_menu:" + res.UUID}}}
+	if w.req.InstantDeploy {
+		text += "\n🚀 The first deployment is queued."
+		rows = append(rows, []gotgbot.InlineKeyboardButton{{Text: "🚚 Deployments", CallbackData: fmt.Sprintf("app_deployments:%s:1", res.UUID)}})
+	}
+	opts.ReplyMarkup = gotgbot.InlineKeyboardMarkup{InlineKeyboard: rows}
+	_, _, err = b.EditMessageText(text, opts)
+	return err
+}
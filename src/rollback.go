@@ -0,0 +1,122 @@
+package src
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"strings"
+
+	"coolifymanager/src/config"
+	coolifyPkg "coolifymanager/src/coolity"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+)
+
+// rollbackTarget resolves "<app uuid>:<deployment uuid>" to the application and the
+// revision that deployment shipped. For Docker image applications Coolify records
+// the image tag in the deployment's commit field.
+func rollbackTarget(reqCtx context.Context, id string) (*coolifyPkg.ApplicationDetail, string, error) {
+	appUUID, deploymentUUID, _ := strings.Cut(id, ":")
+	app, err := config.Coolify.GetApplicationByUUIDWithContext(reqCtx, appUUID)
+	if err != nil {
+		return nil, "", err
+	}
+	deployment, err := config.Coolify.GetDeploymentByUUIDWithContext(reqCtx, deploymentUUID)
+	if err != nil {
+		return nil, "", err
+	}
+	if deployment.Commit == "" || deployment.Commit == "HEAD" {
+		return nil, "", errors.New("deployment has no pinned commit or image tag")
+	}
+	return app, deployment.Commit, nil
+}
+
+func lookupRollback(reqCtx context.Context, id string) (string, error) {
+	app, revision, err := rollbackTarget(reqCtx, id)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s back to %s", app.Name, shortCommit(revision)), nil
+}
+
+func executeRollback(reqCtx context.Context, _ *gotgbot.Bot, id string) (actionResult, error) {
+	app, revision, err := rollbackTarget(reqCtx, id)
+	if err != nil {
+		return actionResult{}, err
+	}
+
+	patch := coolifyPkg.ApplicationPatch{GitCommitSHA: &revision}
+	if app.BuildPack == "dockerimage" {
+		patch = coolifyPkg.ApplicationPatch{DockerRegistryImageTag: &revision}
+	}
+	if err := config.Coolify.UpdateApplicationWithContext(reqCtx, app.UUID, patch); err != nil {
+		return actionResult{}, err
+	}
+
+	res, err := config.Coolify.StartApplicationDeploymentWithContext(reqCtx, app.UUID, false, false)
+	if err != nil {
+		return actionResult{}, err
+	}
+
+	text := fmt.Sprintf(
+		"⏪ Rollback of <b>%s</b> to <code>%s</code> queued!\nDeployment UUID: <code>%s</code>",
+		html.EscapeString(app.Name), html.EscapeString(shortCommit(revision)), res.DeploymentUUID,
+	)
+	// The pin stays until the user unpins, so keep the button while the deployment is tracked.
+	return actionResult{
+		text:           text,
+		appUUID:        app.UUID,
+		deploymentUUID: res.DeploymentUUID,
+		rows:           [][]gotgbot.InlineKeyboardButton{unpinRow(app.UUID)},
+	}, nil
+}
+
+func unpinRow(appUUID string) []gotgbot.InlineKeyboardButton {
+	return []gotgbot.InlineKeyboardButton{{Text: "📌 Unpin (follow branch HEAD)", CallbackData: "unpin:" + appUUID}}
+}
+
+func rollbackDoneMarkup(id string) gotgbot.InlineKeyboardMarkup {
+	appUUID, _, _ := strings.Cut(id, ":")
+	return gotgbot.InlineKeyboardMarkup{InlineKeyboard: [][]gotgbot.InlineKeyboardButton{
+		unpinRow(appUUID),
+		{{Text: "🔙 Back", CallbackData: "project_menu:" + appUUID}},
+	}}
+}
+
+func rollbackHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	cb := ctx.CallbackQuery
+	if !ensureAllowed(b, ctx) {
+		return nil
+	}
+	_, _ = cb.Answer(b, nil)
+
+	return askConfirmation(b, ctx, "rollback", strings.TrimPrefix(cb.Data, "rollback:"))
+}
+
+// unpinHandler resets a rolled-back git application so future deploys build the branch head again.
+func unpinHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	cb := ctx.CallbackQuery
+	if !ensureAllowed(b, ctx) {
+		return nil
+	}
+	_, _ = cb.Answer(b, nil)
+
+	uuid := strings.TrimPrefix(cb.Data, "unpin:")
+	head := "HEAD"
+	err := config.Coolify.UpdateApplicationWithContext(updateContext(ctx), uuid, coolifyPkg.ApplicationPatch{GitCommitSHA: &head})
+	recordAudit(b, ctx.EffectiveUser, "unpin", uuid, "", "", err)
+	if err != nil {
+		_, _, err = cb.Message.EditText(b, "❌ Unpin failed: "+errorText(err), nil)
+		return err
+	}
+
+	_, _, err = cb.Message.EditText(b, "📌 Unpinned. The next deployment builds the latest commit of the branch.", &gotgbot.EditMessageTextOpts{
+		ReplyMarkup: gotgbot.InlineKeyboardMarkup{InlineKeyboard: [][]gotgbot.InlineKeyboardButton{
+			{{Text: "🚀 Deploy now", CallbackData: "deploy:" + uuid}},
+			{{Text: "🔙 Back", CallbackData: "project_menu:" + uuid}},
+		}},
+	})
+	return err
+}
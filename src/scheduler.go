@@ -0,0 +1,228 @@
+package src
+
+import (
+	"fmt"
+	"html"
+	"log"
+	"strings"
+	"time"
+
+	"coolifymanager/src/config"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+)
+
+const scheduleUsage = "/schedule add <deploy|restart|stop> <app> <cron>\n/schedule list\n/schedule remove <id>"
+
+var scheduleActions = map[string]bool{"deploy": true, "restart": true, "stop": true}
+
+// StartScheduler runs stored schedules at the top of every matching minute.
+// Cron expressions are evaluated in the bot's local time zone (TZ).
+func StartScheduler(b *gotgbot.Bot) {
+	go func() {
+		for {
+			next := time.Now().Truncate(time.Minute).Add(time.Minute)
+			time.Sleep(time.Until(next))
+
+			for _, s := range config.Schedules() {
+				spec, err := parseCron(s.Spec)
+				if err != nil {
+					log.Printf("scheduler: schedule %s has invalid spec %q: %v", s.ID, s.Spec, err)
+					continue
+				}
+				if spec.matches(next) {
+					go runSchedule(b, s)
+				}
+			}
+		}
+	}()
+}
+
+// runSchedule performs a scheduled action and reports the outcome to the chat that created it.
+// The creator's permissions are checked on every run so revoked roles take effect.
+func runSchedule(b *gotgbot.Bot, s config.Schedule) {
+	name := html.EscapeString(s.AppName)
+	report := func(text string) *gotgbot.Message {
+		msg, err := b.SendMessage(s.ChatID, text, &gotgbot.SendMessageOpts{ParseMode: "HTML"})
+		if err != nil {
+			log.Printf("scheduler: failed to report schedule %s to %d: %v", s.ID, s.ChatID, err)
+		}
+		return msg
+	}
+
+	if !isAllowed(config.Context(), s.CreatedBy, s.Action, s.AppUUID) {
+		report(fmt.Sprintf("⏰ Schedule #%s skipped: its creator may no longer %s <b>%s</b>.", s.ID, s.Action, name))
+		return
+	}
+
+	var (
+		deploymentUUID string
+		text           string
+		err            error
+	)
+	switch s.Action {
+	case "deploy":
+		res, deployErr := config.Coolify.StartApplicationDeployment(s.AppUUID, false, false)
+		if err = deployErr; err == nil {
+			deploymentUUID = res.DeploymentUUID
+			text = fmt.Sprintf("⏰ Scheduled deployment of <b>%s</b> queued (#%s).", name, s.ID)
+		}
+	case "restart":
+		res, restartErr := config.Coolify.RestartApplicationByUUID(s.AppUUID)
+		if err = restartErr; err == nil {
+			deploymentUUID = res.DeploymentUUID
+			text = fmt.Sprintf("⏰ Scheduled restart of <b>%s</b> queued (#%s).", name, s.ID)
+		}
+	case "stop":
+		res, stopErr := config.Coolify.StopApplicationByUUID(s.AppUUID)
+		if err = stopErr; err == nil {
+			text = fmt.Sprintf("⏰ Scheduled stop of <b>%s</b> (#%s): %s", name, s.ID, html.EscapeString(res.Message))
+		}
+	default:
+		return
+	}
+	recordAudit(b, &gotgbot.User{Id: s.CreatedBy, FirstName: "schedule #" + s.ID}, s.Action, s.AppUUID, s.AppName, text, err)
+	if err != nil {
+		report(fmt.Sprintf("❌ Scheduled %s of <b>%s</b> failed (#%s): %s", s.Action, name, s.ID, html.EscapeString(errorText(err))))
+		return
+	}
+
+	msg := report(text)
+	if msg != nil && deploymentUUID != "" {
+		trackDeployment(b, msg.Chat.Id, msg.MessageId, s.AppUUID, deploymentUUID, "Scheduled "+s.Action)
+	}
+}
+
+func scheduleCommandHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+	args := commandArgs(msg)
+	if len(args) == 0 {
+		_, err := replyHTML(b, msg, "Usage:\n<code>"+html.EscapeString(scheduleUsage)+"</code>", nil)
+		return err
+	}
+
+	switch strings.ToLower(args[0]) {
+	case "add":
+		return scheduleAdd(b, ctx, args[1:])
+	case "list", "ls":
+		return scheduleList(b, ctx)
+	case "remove", "rm", "del":
+		return scheduleRemove(b, ctx, args[1:])
+	}
+	_, err := replyHTML(b, msg, "Usage:\n<code>"+html.EscapeString(scheduleUsage)+"</code>", nil)
+	return err
+}
+
+func scheduleAdd(b *gotgbot.Bot, ctx *ext.Context, args []string) error {
+	msg := ctx.EffectiveMessage
+	if len(args) < 3 {
+		_, err := replyHTML(b, msg, "Usage: <code>/schedule add &lt;deploy|restart|stop&gt; &lt;app&gt; &lt;cron&gt;</code>\nExample: <code>/schedule add restart api 0 4 * * *</code>", nil)
+		return err
+	}
+
+	action := strings.ToLower(args[0])
+	if !scheduleActions[action] {
+		_, err := msg.Reply(b, "❌ Action must be deploy, restart or stop.", nil)
+		return err
+	}
+	specText := strings.Join(args[2:], " ")
+	spec, err := parseCron(specText)
+	if err != nil {
+		_, err = replyHTML(b, msg, "❌ Invalid cron expression: "+html.EscapeString(errorText(err)), nil)
+		return err
+	}
+
+	apps, err := allApplications(updateContext(ctx))
+	if err != nil {
+		_, err = msg.Reply(b, "❌ Failed to fetch projects: "+errorText(err), nil)
+		return err
+	}
+	matches := matchApplications(apps, args[1])
+	if len(matches) != 1 {
+		_, err = replyHTML(b, msg, fmt.Sprintf("❌ <code>%s</code> matches %d applications. Use the exact name or UUID.", html.EscapeString(args[1]), len(matches)), nil)
+		return err
+	}
+	app := matches[0]
+	if !isAllowed(updateContext(ctx), ctx.EffectiveUser.Id, action, app.UUID) {
+		_, err = msg.Reply(b, "🚫 You are not authorized.", nil)
+		return err
+	}
+
+	s, err := config.AddSchedule(config.Schedule{
+		Spec:      specText,
+		Action:    action,
+		AppUUID:   app.UUID,
+		AppName:   app.Name,
+		ChatID:    ctx.EffectiveChat.Id,
+		CreatedBy: ctx.EffectiveUser.Id,
+	})
+	recordAudit(b, ctx.EffectiveUser, "schedule_add", app.UUID, app.Name, action+" at "+specText, err)
+	if err != nil {
+		_, err = msg.Reply(b, "❌ Failed to save schedule: "+errorText(err), nil)
+		return err
+	}
+
+	text := fmt.Sprintf("✅ Schedule #%s: %s <b>%s</b> at <code>%s</code>.", s.ID, action, html.EscapeString(app.Name), html.EscapeString(specText))
+	if next, ok := spec.next(time.Now()); ok {
+		text += "\nNext run: " + next.Format("2006-01-02 15:04 MST")
+	}
+	_, err = replyHTML(b, msg, text, nil)
+	return err
+}
+
+func scheduleList(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+	if !isAllowed(updateContext(ctx), ctx.EffectiveUser.Id, "list_projects", "") {
+		_, err := msg.Reply(b, "🚫 You are not authorized.", nil)
+		return err
+	}
+
+	var sb strings.Builder
+	for _, s := range config.Schedules() {
+		if s.ChatID != ctx.EffectiveChat.Id {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("#%s %s <b>%s</b> <code>%s</code>", s.ID, s.Action, html.EscapeString(s.AppName), html.EscapeString(s.Spec)))
+		if spec, err := parseCron(s.Spec); err == nil {
+			if next, ok := spec.next(time.Now()); ok {
+				sb.WriteString(" — next " + next.Format("Jan 2 15:04"))
+			}
+		}
+		sb.WriteString("\n")
+	}
+	if sb.Len() == 0 {
+		_, err := msg.Reply(b, "No schedules in this chat.", nil)
+		return err
+	}
+	_, err := replyHTML(b, msg, "<b>⏰ Schedules</b>\n"+sb.String(), nil)
+	return err
+}
+
+func scheduleRemove(b *gotgbot.Bot, ctx *ext.Context, args []string) error {
+	msg := ctx.EffectiveMessage
+	if len(args) == 0 {
+		_, err := replyHTML(b, msg, "Usage: <code>/schedule remove &lt;id&gt;</code>", nil)
+		return err
+	}
+
+	id := strings.TrimPrefix(args[0], "#")
+	s, ok := config.GetSchedule(id)
+	if !ok || s.ChatID != ctx.EffectiveChat.Id {
+		_, err := msg.Reply(b, "❌ No schedule with that ID in this chat.", nil)
+		return err
+	}
+	if s.CreatedBy != ctx.EffectiveUser.Id && config.GlobalRole(ctx.EffectiveUser.Id) < config.RoleAdmin {
+		_, err := msg.Reply(b, "🚫 Only the creator or an admin can remove this schedule.", nil)
+		return err
+	}
+
+	_, err := config.RemoveSchedule(id)
+	recordAudit(b, ctx.EffectiveUser, "schedule_remove", s.AppUUID, s.AppName, fmt.Sprintf("#%s %s at %s", id, s.Action, s.Spec), err)
+	if err != nil {
+		_, err = msg.Reply(b, "❌ Failed to remove schedule: "+errorText(err), nil)
+		return err
+	}
+	_, err = msg.Reply(b, fmt.Sprintf("🗑 Schedule #%s removed.", id), nil)
+	return err
+}
@@ -0,0 +1,166 @@
+package src
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"coolifymanager/src/config"
+	coolifyPkg "coolifymanager/src/coolity"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+)
+
+// maxServerResourcesShown caps each resource group so busy servers stay under Telegram's message limit.
+const maxServerResourcesShown = 25
+
+func serverStateIcon(s coolifyPkg.Server) string {
+	switch {
+	case s.Settings.IsReachable && s.Settings.IsUsable:
+		return "🟢"
+	case s.Settings.IsReachable:
+		return "🟠"
+	default:
+		return "🔴"
+	}
+}
+
+func yesNo(v bool) string {
+	if v {
+		return "yes"
+	}
+	return "no"
+}
+
+// resourceGroup maps Coolify's resource type to a heading; every database type is "standalone-*".
+func resourceGroup(kind string) string {
+	switch kind {
+	case "application":
+		return "📦 Applications"
+	case "service":
+		return "🧩 Services"
+	default:
+		return "🗄 Databases"
+	}
+}
+
+func listServersHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	if !ensureAllowed(b, ctx) {
+		return nil
+	}
+	cb := ctx.CallbackQuery
+	_, _ = cb.Answer(b, nil)
+
+	servers, err := config.Coolify.ListServersWithContext(updateContext(ctx))
+	if err != nil {
+		_, _, _ = cb.Message.EditText(b, "❌ Failed to fetch servers: "+errorText(err), nil)
+		return err
+	}
+	if len(servers) == 0 {
+		_, _, err = cb.Message.EditText(b, "No servers available.", nil)
+		return err
+	}
+
+	var buttons [][]gotgbot.InlineKeyboardButton
+	for _, s := range servers {
+		buttons = append(buttons, []gotgbot.InlineKeyboardButton{
+			{Text: fmt.Sprintf("%s %s (%s)", serverStateIcon(s), s.Name, s.IP), CallbackData: "server_menu:" + s.UUID},
+		})
+	}
+	buttons = append(buttons, []gotgbot.InlineKeyboardButton{{Text: "🔙 Back", CallbackData: "list_projects:1"}})
+
+	_, _, err = cb.Message.EditText(b, "<b>🖥 Servers</b>\n🟢 usable · 🟠 reachable, not usable · 🔴 unreachable", &gotgbot.EditMessageTextOpts{
+		ParseMode:   "HTML",
+		ReplyMarkup: gotgbot.InlineKeyboardMarkup{InlineKeyboard: buttons},
+	})
+	return err
+}
+
+func serverMenuHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	cb := ctx.CallbackQuery
+	if !ensureAllowed(b, ctx) {
+		return nil
+	}
+	_, _ = cb.Answer(b, nil)
+
+	uuid := strings.TrimPrefix(cb.Data, "server_menu:")
+	server, err := config.Coolify.GetServerWithContext(updateContext(ctx), uuid)
+	if err != nil {
+		_, _, err = cb.Message.EditText(b, "❌ Failed to load server: "+errorText(err), nil)
+		return err
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("<b>%s %s</b>\n", serverStateIcon(*server), html.EscapeString(server.Name)))
+	if server.Description != "" {
+		sb.WriteString(html.EscapeString(server.Description) + "\n")
+	}
+	sb.WriteString(fmt.Sprintf("🔌 <code>%s@%s:%d</code>\n", html.EscapeString(server.User), html.EscapeString(server.IP), server.Port))
+	sb.WriteString(fmt.Sprintf("Reachable: %s · Usable: %s\n", yesNo(server.Settings.IsReachable), yesNo(server.Settings.IsUsable)))
+	if server.Settings.IsBuildServer {
+		sb.WriteString("🏗 Build server\n")
+	}
+	if server.ProxyType != "" {
+		sb.WriteString(fmt.Sprintf("🔀 Proxy: %s\n", html.EscapeString(server.ProxyType)))
+	}
+
+	resources, err := config.Coolify.GetServerResourcesWithContext(updateContext(ctx), uuid)
+	if err != nil {
+		sb.WriteString("\n❌ Failed to fetch resources: " + html.EscapeString(errorText(err)))
+	} else if len(resources) == 0 {
+		sb.WriteString("\nNo resources on this server.")
+	} else {
+		groups := make(map[string][]coolifyPkg.ServerResource)
+		for _, r := range resources {
+			groups[resourceGroup(r.Type)] = append(groups[resourceGroup(r.Type)], r)
+		}
+		for _, heading := range []string{"📦 Applications", "🗄 Databases", "🧩 Services"} {
+			if len(groups[heading]) == 0 {
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("\n<b>%s (%d)</b>\n", heading, len(groups[heading])))
+			for i, r := range groups[heading] {
+				if i == maxServerResourcesShown {
+					sb.WriteString(fmt.Sprintf("… and %d more\n", len(groups[heading])-i))
+					break
+				}
+				sb.WriteString(fmt.Sprintf("• %s — <code>%s</code>\n", html.EscapeString(r.Name), html.EscapeString(orUnknown(r.Status))))
+			}
+		}
+	}
+
+	btns := [][]gotgbot.InlineKeyboardButton{
+		{{Text: "🔁 Re-validate connection", CallbackData: "srv_validate:" + uuid}},
+		{{Text: "🔙 Back", CallbackData: "list_servers"}},
+	}
+	_, _, err = cb.Message.EditText(b, sb.String(), &gotgbot.EditMessageTextOpts{
+		ParseMode:   "HTML",
+		ReplyMarkup: gotgbot.InlineKeyboardMarkup{InlineKeyboard: btns},
+	})
+	return err
+}
+
+func serverValidateHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	cb := ctx.CallbackQuery
+	if !ensureAllowed(b, ctx) {
+		return nil
+	}
+	_, _ = cb.Answer(b, nil)
+
+	uuid := strings.TrimPrefix(cb.Data, "srv_validate:")
+	res, err := config.Coolify.ValidateServerWithContext(updateContext(ctx), uuid)
+	if err != nil {
+		recordAudit(b, ctx.EffectiveUser, "srv_validate", uuid, "", "", err)
+		_, _, err = cb.Message.EditText(b, "❌ Validation failed: "+errorText(err), nil)
+		return err
+	}
+	recordAudit(b, ctx.EffectiveUser, "srv_validate", uuid, "", res.Message, nil)
+
+	_, _, err = cb.Message.EditText(b, "🔁 "+res.Message+"\nRefresh the server in a few seconds to see the result.", &gotgbot.EditMessageTextOpts{
+		ReplyMarkup: gotgbot.InlineKeyboardMarkup{InlineKeyboard: [][]gotgbot.InlineKeyboardButton{
+			{{Text: "🔄 Refresh", CallbackData: "server_menu:" + uuid}},
+		}},
+	})
+	return err
+}
@@ -0,0 +1,192 @@
+package src
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+
+	"coolifymanager/src/config"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+)
+
+func serviceName(reqCtx context.Context, uuid string) (string, error) {
+	svc, err := config.Coolify.GetServiceByUUIDWithContext(reqCtx, uuid)
+	if err != nil {
+		return "", err
+	}
+	return svc.Name, nil
+}
+
+func executeServiceStop(reqCtx context.Context, _ *gotgbot.Bot, uuid string) (actionResult, error) {
+	res, err := config.Coolify.StopServiceWithContext(reqCtx, uuid)
+	if err != nil {
+		return actionResult{}, err
+	}
+	return actionResult{text: "🛑 " + html.EscapeString(res.Message)}, nil
+}
+
+func listServicesHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	if !ensureAllowed(b, ctx) {
+		return nil
+	}
+	cb := ctx.CallbackQuery
+	_, _ = cb.Answer(b, nil)
+
+	page := parsePageFromCallback(cb.Data, "list_services")
+	result, err := config.Coolify.ListServicesWithContext(updateContext(ctx), page, defaultPerPage)
+	if err != nil {
+		_, _, _ = cb.Message.EditText(b, "❌ Failed to fetch services: "+errorText(err), nil)
+		return err
+	}
+
+	items := result.Results()
+	if len(items) == 0 {
+		_, _, err = cb.Message.EditText(b, "No services available.", nil)
+		return err
+	}
+
+	currentPage, totalPages := derivePage(result.PageInfo(), len(items), defaultPerPage, page)
+	var buttons [][]gotgbot.InlineKeyboardButton
+	for _, svc := range items {
+		text := fmt.Sprintf("🧩 %s (%s)", svc.Name, svc.Status)
+		buttons = append(buttons, []gotgbot.InlineKeyboardButton{
+			{Text: text, CallbackData: fmt.Sprintf("service_menu:%s:%d", svc.UUID, currentPage)},
+		})
+	}
+	buttons = append(buttons,
+		buildPaginationRow("list_services", currentPage, totalPages),
+		[]gotgbot.InlineKeyboardButton{{Text: "🔙 Back", CallbackData: "list_projects:1"}},
+	)
+
+	message := fmt.Sprintf("<b>🧩 Select a service</b>\nPage %d of %d", currentPage, totalPages)
+	_, _, err = cb.Message.EditText(b, message, &gotgbot.EditMessageTextOpts{
+		ParseMode:   "HTML",
+		ReplyMarkup: gotgbot.InlineKeyboardMarkup{InlineKeyboard: buttons},
+	})
+	return err
+}
+
+func serviceMenuHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	cb := ctx.CallbackQuery
+	if !ensureAllowed(b, ctx) {
+		return nil
+	}
+	_, _ = cb.Answer(b, nil)
+
+	parts := strings.Split(strings.TrimPrefix(cb.Data, "service_menu:"), ":")
+	uuid := parts[0]
+	fromPage := 1
+	if len(parts) > 1 {
+		if p, err := strconv.Atoi(parts[1]); err == nil && p > 0 {
+			fromPage = p
+		}
+	}
+
+	svc, err := config.Coolify.GetServiceByUUIDWithContext(updateContext(ctx), uuid)
+	if err != nil {
+		_, _, err = cb.Message.EditText(b, "❌ Failed to load service: "+errorText(err), nil)
+		return err
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("<b>🧩 %s</b>\n📄 Status: <code>%s</code>\n", html.EscapeString(svc.Name), svc.Status))
+	if svc.ServiceType != "" {
+		sb.WriteString(fmt.Sprintf("🏷 Type: %s\n", html.EscapeString(svc.ServiceType)))
+	}
+	if svc.Description != "" {
+		sb.WriteString(html.EscapeString(svc.Description) + "\n")
+	}
+	for _, c := range svc.Applications {
+		sb.WriteString(fmt.Sprintf("\n📦 %s — <code>%s</code>", html.EscapeString(c.Name), c.Status))
+		if c.FQDN != "" {
+			sb.WriteString("\n    🌐 " + html.EscapeString(c.FQDN))
+		}
+	}
+	for _, c := range svc.Databases {
+		sb.WriteString(fmt.Sprintf("\n🗄 %s — <code>%s</code>", html.EscapeString(c.Name), c.Status))
+	}
+
+	btns := [][]gotgbot.InlineKeyboardButton{
+		{{Text: "▶️ Start", CallbackData: "svc_start:" + uuid}, {Text: "🔄 Restart", CallbackData: "svc_restart:" + uuid}},
+		{{Text: "🌱 Envs", CallbackData: "svc_envs:" + uuid}, {Text: "🛑 Stop", CallbackData: "svc_stop:" + uuid}},
+		{{Text: "🔙 Back", CallbackData: fmt.Sprintf("list_services:%d", fromPage)}},
+	}
+
+	_, _, err = cb.Message.EditText(b, sb.String(), &gotgbot.EditMessageTextOpts{
+		ParseMode:   "HTML",
+		ReplyMarkup: gotgbot.InlineKeyboardMarkup{InlineKeyboard: btns},
+	})
+	return err
+}
+
+// serviceLifecycleHandler handles svc_start and svc_restart, which need no confirmation.
+func serviceLifecycleHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	cb := ctx.CallbackQuery
+	if !ensureAllowed(b, ctx) {
+		return nil
+	}
+	_, _ = cb.Answer(b, nil)
+
+	action, uuid, _ := strings.Cut(cb.Data, ":")
+	call, icon := config.Coolify.StartServiceWithContext, "▶️"
+	if action == "svc_restart" {
+		call, icon = config.Coolify.RestartServiceWithContext, "🔄"
+	}
+
+	res, err := call(updateContext(ctx), uuid)
+	if err != nil {
+		recordAudit(b, ctx.EffectiveUser, action, uuid, "", "", err)
+		_, _, err = cb.Message.EditText(b, "❌ Service action failed: "+errorText(err), nil)
+		return err
+	}
+	recordAudit(b, ctx.EffectiveUser, action, uuid, "", res.Message, nil)
+
+	_, _, err = cb.Message.EditText(b, icon+" "+res.Message, &gotgbot.EditMessageTextOpts{
+		ReplyMarkup: gotgbot.InlineKeyboardMarkup{InlineKeyboard: [][]gotgbot.InlineKeyboardButton{
+			{{Text: "🔙 Back", CallbackData: "service_menu:" + uuid}},
+		}},
+	})
+	return err
+}
+
+func serviceStopHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	cb := ctx.CallbackQuery
+	if !ensureAllowed(b, ctx) {
+		return nil
+	}
+	_, _ = cb.Answer(b, nil)
+
+	return askConfirmation(b, ctx, "service_stop", strings.TrimPrefix(cb.Data, "svc_stop:"))
+}
+
+func serviceEnvsHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	cb := ctx.CallbackQuery
+	if !ensureAllowed(b, ctx) {
+		return nil
+	}
+	_, _ = cb.Answer(b, nil)
+
+	uuid := strings.TrimPrefix(cb.Data, "svc_envs:")
+	envs, err := config.Coolify.GetServiceEnvsByUUIDWithContext(updateContext(ctx), uuid)
+	if err != nil {
+		_, _, _ = cb.Message.EditText(b, "❌ Failed to fetch env vars: "+errorText(err), nil)
+		return err
+	}
+
+	text := formatEnvList(envs)
+	if len(envs) == 0 {
+		text = "This service has no environment variables."
+	}
+	btns := [][]gotgbot.InlineKeyboardButton{
+		{{Text: "🔙 Back", CallbackData: "service_menu:" + uuid}},
+	}
+	_, _, err = cb.Message.EditText(b, text, &gotgbot.EditMessageTextOpts{
+		ParseMode:   "HTML",
+		ReplyMarkup: gotgbot.InlineKeyboardMarkup{InlineKeyboard: btns},
+	})
+	return err
+}
@@ -0,0 +1,370 @@
+package src
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"coolifymanager/src/config"
+	coolifyPkg "coolifymanager/src/coolity"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+)
+
+const settingChangeTTL = 5 * time.Minute
+
+// appSetting is one editable application field. Fields with choices are picked
+// from buttons; the rest are typed, with "-" clearing the value unless numeric.
+type appSetting struct {
+	key     string
+	label   string
+	current func(app *coolifyPkg.ApplicationDetail) string
+	apply   func(patch *coolifyPkg.ApplicationPatch, value string) error
+	choices []string
+	// numeric fields can't be cleared, only set to another number.
+	numeric bool
+}
+
+func setString(field func(p *coolifyPkg.ApplicationPatch) **string) func(*coolifyPkg.ApplicationPatch, string) error {
+	return func(p *coolifyPkg.ApplicationPatch, value string) error {
+		*field(p) = &value
+		return nil
+	}
+}
+
+func setInt(field func(p *coolifyPkg.ApplicationPatch) **int) func(*coolifyPkg.ApplicationPatch, string) error {
+	return func(p *coolifyPkg.ApplicationPatch, value string) error {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return fmt.Errorf("%q is not a valid number", value)
+		}
+		*field(p) = &n
+		return nil
+	}
+}
+
+var appSettings = []appSetting{
+	{
+		key: "dom", label: "🌐 Domains",
+		current: func(a *coolifyPkg.ApplicationDetail) string { return a.FQDN },
+		apply:   setString(func(p *coolifyPkg.ApplicationPatch) **string { return &p.Domains }),
+	},
+	{
+		key: "br", label: "🌿 Branch",
+		current: func(a *coolifyPkg.ApplicationDetail) string { return a.GitBranch },
+		apply:   setString(func(p *coolifyPkg.ApplicationPatch) **string { return &p.GitBranch }),
+	},
+	{
+		key: "bp", label: "🧰 Build pack",
+		current: func(a *coolifyPkg.ApplicationDetail) string { return a.BuildPack },
+		apply:   setString(func(p *coolifyPkg.ApplicationPatch) **string { return &p.BuildPack }),
+		choices: buildPacks,
+	},
+	{
+		key: "port", label: "🔌 Ports",
+		current: func(a *coolifyPkg.ApplicationDetail) string { return a.PortsExposes },
+		apply:   setString(func(p *coolifyPkg.ApplicationPatch) **string { return &p.PortsExposes }),
+	},
+	{
+		key: "inst", label: "📥 Install command",
+		current: func(a *coolifyPkg.ApplicationDetail) string { return a.InstallCommand },
+		apply:   setString(func(p *coolifyPkg.ApplicationPatch) **string { return &p.InstallCommand }),
+	},
+	{
+		key: "bld", label: "🏗 Build command",
+		current: func(a *coolifyPkg.ApplicationDetail) string { return a.BuildCommand },
+		apply:   setString(func(p *coolifyPkg.ApplicationPatch) **string { return &p.BuildCommand }),
+	},
+	{
+		key: "run", label: "▶️ Start command",
+		current: func(a *coolifyPkg.ApplicationDetail) string { return a.StartCommand },
+		apply:   setString(func(p *coolifyPkg.ApplicationPatch) **string { return &p.StartCommand }),
+	},
+	{
+		key: "hc", label: "❤️ Health check",
+		current: func(a *coolifyPkg.ApplicationDetail) string { return strconv.FormatBool(a.HealthCheckEnabled) },
+		apply: func(p *coolifyPkg.ApplicationPatch, value string) error {
+			enabled := value == "true"
+			p.HealthCheckEnabled = &enabled
+			return nil
+		},
+		choices: []string{"true", "false"},
+	},
+	{
+		key: "hcp", label: "🩺 Health path",
+		current: func(a *coolifyPkg.ApplicationDetail) string { return a.HealthCheckPath },
+		apply:   setString(func(p *coolifyPkg.ApplicationPatch) **string { return &p.HealthCheckPath }),
+	},
+	{
+		key: "hco", label: "🩺 Health port",
+		current: func(a *coolifyPkg.ApplicationDetail) string { return a.HealthCheckPort },
+		apply:   setString(func(p *coolifyPkg.ApplicationPatch) **string { return &p.HealthCheckPort }),
+	},
+	{
+		key: "hci", label: "⏱ Health interval (s)",
+		current: func(a *coolifyPkg.ApplicationDetail) string { return strconv.Itoa(a.HealthCheckInterval) },
+		apply:   setInt(func(p *coolifyPkg.ApplicationPatch) **int { return &p.HealthCheckInterval }),
+		numeric: true,
+	},
+	{
+		key: "hct", label: "⏱ Health timeout (s)",
+		current: func(a *coolifyPkg.ApplicationDetail) string { return strconv.Itoa(a.HealthCheckTimeout) },
+		apply:   setInt(func(p *coolifyPkg.ApplicationPatch) **int { return &p.HealthCheckTimeout }),
+		numeric: true,
+	},
+	{
+		key: "hcr", label: "🔁 Health retries",
+		current: func(a *coolifyPkg.ApplicationDetail) string { return strconv.Itoa(a.HealthCheckRetries) },
+		apply:   setInt(func(p *coolifyPkg.ApplicationPatch) **int { return &p.HealthCheckRetries }),
+		numeric: true,
+	},
+}
+
+func findSetting(key string) (appSetting, bool) {
+	for _, s := range appSettings {
+		if s.key == key {
+			return s, true
+		}
+	}
+	return appSetting{}, false
+}
+
+// settingChange is an edited field waiting for the user to confirm the before/after summary.
+type settingChange struct {
+	appUUID   string
+	userID    int64
+	label     string
+	before    string
+	after     string
+	patch     coolifyPkg.ApplicationPatch
+	expiresAt time.Time
+}
+
+var (
+	settingMu      sync.Mutex
+	settingChanges = make(map[string]settingChange)
+)
+
+func storeSettingChange(c settingChange) string {
+	settingMu.Lock()
+	defer settingMu.Unlock()
+
+	now := time.Now()
+	for nonce, existing := range settingChanges {
+		if now.After(existing.expiresAt) {
+			delete(settingChanges, nonce)
+		}
+	}
+	nonce := newNonce()
+	settingChanges[nonce] = c
+	return nonce
+}
+
+func takeSettingChange(nonce string, userID int64) (settingChange, bool) {
+	settingMu.Lock()
+	defer settingMu.Unlock()
+
+	c, ok := settingChanges[nonce]
+	if !ok || c.userID != userID {
+		return settingChange{}, false
+	}
+	delete(settingChanges, nonce)
+	return c, time.Now().Before(c.expiresAt)
+}
+
+func orNotSet(v string) string {
+	if v == "" {
+		return "(not set)"
+	}
+	return v
+}
+
+func appSettingsHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	cb := ctx.CallbackQuery
+	if !ensureAllowed(b, ctx) {
+		return nil
+	}
+	_, _ = cb.Answer(b, nil)
+
+	uuid := strings.TrimPrefix(cb.Data, "app_settings:")
+	app, err := config.Coolify.GetApplicationByUUIDWithContext(updateContext(ctx), uuid)
+	if err != nil {
+		_, _, err = cb.Message.EditText(b, "❌ Failed to load project: "+errorText(err), nil)
+		return err
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("<b>⚙️ %s settings</b>\n\n", html.EscapeString(app.Name)))
+	var btns [][]gotgbot.InlineKeyboardButton
+	var row []gotgbot.InlineKeyboardButton
+	for _, s := range appSettings {
+		sb.WriteString(fmt.Sprintf("%s: <code>%s</code>\n", s.label, html.EscapeString(orNotSet(s.current(app)))))
+		row = append(row, gotgbot.InlineKeyboardButton{Text: s.label, CallbackData: fmt.Sprintf("set:%s:%s", uuid, s.key)})
+		if len(row) == 2 {
+			btns = append(btns, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		btns = append(btns, row)
+	}
+	btns = append(btns, []gotgbot.InlineKeyboardButton{{Text: "🔙 Back", CallbackData: "project_menu:" + uuid}})
+
+	_, _, err = cb.Message.EditText(b, sb.String(), &gotgbot.EditMessageTextOpts{
+		ParseMode:   "HTML",
+		ReplyMarkup: gotgbot.InlineKeyboardMarkup{InlineKeyboard: btns},
+	})
+	return err
+}
+
+// setSettingHandler starts editing a field: "set:<app>:<field>" asks for the new
+// value, "set:<app>:<field>:<choice>" takes it from a button.
+func setSettingHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	cb := ctx.CallbackQuery
+	if !ensureAllowed(b, ctx) {
+		return nil
+	}
+	_, _ = cb.Answer(b, nil)
+
+	parts := strings.SplitN(strings.TrimPrefix(cb.Data, "set:"), ":", 3)
+	if len(parts) < 2 {
+		return nil
+	}
+	appUUID := parts[0]
+	setting, ok := findSetting(parts[1])
+	if !ok {
+		return nil
+	}
+	back := []gotgbot.InlineKeyboardButton{{Text: "✖ Cancel", CallbackData: "app_settings:" + appUUID}}
+
+	if len(parts) == 3 {
+		text, markup := previewSetting(updateContext(ctx), appUUID, ctx.EffectiveUser.Id, setting, parts[2])
+		_, _, err := cb.Message.EditText(b, text, &gotgbot.EditMessageTextOpts{ParseMode: "HTML", ReplyMarkup: markup})
+		return err
+	}
+
+	if len(setting.choices) > 0 {
+		var row []gotgbot.InlineKeyboardButton
+		for _, choice := range setting.choices {
+			row = append(row, gotgbot.InlineKeyboardButton{Text: choice, CallbackData: fmt.Sprintf("set:%s:%s:%s", appUUID, setting.key, choice)})
+		}
+		_, _, err := cb.Message.EditText(b, fmt.Sprintf("Select the new %s:", html.EscapeString(setting.label)), &gotgbot.EditMessageTextOpts{
+			ParseMode:   "HTML",
+			ReplyMarkup: gotgbot.InlineKeyboardMarkup{InlineKeyboard: [][]gotgbot.InlineKeyboardButton{row, back}},
+		})
+		return err
+	}
+
+	chatID := cb.Message.GetChat().Id
+	messageID := cb.Message.GetMessageId()
+	prompt := fmt.Sprintf("✏️ Send the new value for %s, or <code>-</code> to clear it.", html.EscapeString(setting.label))
+	if setting.numeric {
+		prompt = fmt.Sprintf("✏️ Send the new value for %s as a whole number.", html.EscapeString(setting.label))
+	}
+	_, _, err := cb.Message.EditText(b, prompt, &gotgbot.EditMessageTextOpts{
+		ParseMode:   "HTML",
+		ReplyMarkup: gotgbot.InlineKeyboardMarkup{InlineKeyboard: [][]gotgbot.InlineKeyboardButton{back}},
+	})
+	if err != nil {
+		return err
+	}
+
+	awaitInput(chatID, ctx.EffectiveUser.Id, envInputTTL, func(b *gotgbot.Bot, ctx *ext.Context) error {
+		value := strings.TrimSpace(ctx.EffectiveMessage.Text)
+		if value == "-" && !setting.numeric {
+			value = ""
+		}
+		text, markup := previewSetting(updateContext(ctx), appUUID, ctx.EffectiveUser.Id, setting, value)
+		_, _, err := b.EditMessageText(text, &gotgbot.EditMessageTextOpts{
+			ChatId:      chatID,
+			MessageId:   messageID,
+			ParseMode:   "HTML",
+			ReplyMarkup: markup,
+		})
+		return err
+	})
+	return nil
+}
+
+// previewSetting builds the before/after summary and stores the pending patch behind a nonce.
+func previewSetting(reqCtx context.Context, appUUID string, userID int64, setting appSetting, value string) (string, gotgbot.InlineKeyboardMarkup) {
+	back := gotgbot.InlineKeyboardMarkup{InlineKeyboard: [][]gotgbot.InlineKeyboardButton{
+		{{Text: "🔙 Back to settings", CallbackData: "app_settings:" + appUUID}},
+	}}
+
+	app, err := config.Coolify.GetApplicationByUUIDWithContext(reqCtx, appUUID)
+	if err != nil {
+		return "❌ Failed to load project: " + html.EscapeString(errorText(err)), back
+	}
+	var patch coolifyPkg.ApplicationPatch
+	if err := setting.apply(&patch, value); err != nil {
+		return "❌ " + html.EscapeString(errorText(err)), back
+	}
+
+	before := setting.current(app)
+	if before == value {
+		return "ℹ️ " + html.EscapeString(setting.label) + " already has that value.", back
+	}
+
+	nonce := storeSettingChange(settingChange{
+		appUUID:   appUUID,
+		userID:    userID,
+		label:     setting.label,
+		before:    before,
+		after:     value,
+		patch:     patch,
+		expiresAt: time.Now().Add(settingChangeTTL),
+	})
+	text := fmt.Sprintf(
+		"<b>⚙️ %s</b> — %s\n\nBefore: <code>%s</code>\nAfter: <code>%s</code>\n\nSave this change?",
+		html.EscapeString(app.Name), html.EscapeString(setting.label),
+		html.EscapeString(orNotSet(before)), html.EscapeString(orNotSet(value)),
+	)
+	return text, gotgbot.InlineKeyboardMarkup{InlineKeyboard: [][]gotgbot.InlineKeyboardButton{
+		{{Text: "✅ Save", CallbackData: "set_save:" + nonce}, {Text: "✖ Discard", CallbackData: "app_settings:" + appUUID}},
+	}}
+}
+
+func saveSettingHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	cb := ctx.CallbackQuery
+	if !ensureAllowed(b, ctx) {
+		return nil
+	}
+
+	change, ok := takeSettingChange(strings.TrimPrefix(cb.Data, "set_save:"), ctx.EffectiveUser.Id)
+	if !ok {
+		_, _ = cb.Answer(b, &gotgbot.AnswerCallbackQueryOpts{
+			Text:      "⌛ This change has expired. Please edit the setting again.",
+			ShowAlert: true,
+		})
+		return nil
+	}
+	if !isAllowed(updateContext(ctx), change.userID, "set", change.appUUID) {
+		_, _ = cb.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: "🚫 You are not authorized.", ShowAlert: true})
+		return nil
+	}
+	_, _ = cb.Answer(b, nil)
+
+	text := fmt.Sprintf(
+		"✅ %s updated.\n<code>%s</code> → <code>%s</code>\nRedeploy for the change to take effect.",
+		html.EscapeString(change.label), html.EscapeString(orNotSet(change.before)), html.EscapeString(orNotSet(change.after)),
+	)
+	err := config.Coolify.UpdateApplicationWithContext(updateContext(ctx), change.appUUID, change.patch)
+	recordAudit(b, ctx.EffectiveUser, "set", change.appUUID, "", fmt.Sprintf("%s: %s → %s", change.label, orNotSet(change.before), orNotSet(change.after)), err)
+	if err != nil {
+		text = "❌ Update failed: " + html.EscapeString(errorText(err))
+	}
+	_, _, err = cb.Message.EditText(b, text, &gotgbot.EditMessageTextOpts{
+		ParseMode: "HTML",
+		ReplyMarkup: gotgbot.InlineKeyboardMarkup{InlineKeyboard: [][]gotgbot.InlineKeyboardButton{
+			{{Text: "🚀 Redeploy now", CallbackData: "deploy:" + change.appUUID}},
+			{{Text: "🔙 Back to settings", CallbackData: "app_settings:" + change.appUUID}},
+		}},
+	})
+	return err
+}
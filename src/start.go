@@ -5,6 +5,8 @@ import (
 	"html"
 	"time"
 
+	"coolifymanager/src/config"
+
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/PaulSonOfLars/gotgbot/v2/ext"
 )
@@ -24,6 +26,7 @@ Use the menu below to get started.`,
 		InlineKeyboard: [][]gotgbot.InlineKeyboardButton{
 			{
 				{Text: "📋 List Projects", CallbackData: "list_projects:1"},
+				{Text: "🗂 Browse by project", CallbackData: "browse:1"},
 			},
 			{
 				{Text: "🚚 Deployments", CallbackData: "list_deployments:1"},
@@ -31,6 +34,10 @@ Use the menu below to get started.`,
 			},
 			{
 				{Text: "🗄 Databases", CallbackData: "list_databases:1"},
+				{Text: "🧩 Services", CallbackData: "list_services:1"},
+			},
+			{
+				{Text: "🖥 Servers", CallbackData: "list_servers"},
 			},
 			{
 				{Text: "🆘 Support Chat", Url: "https://t.me/GuardxSupport"},
@@ -77,3 +84,56 @@ func pingCommandHandler(b *gotgbot.Bot, ctx *ext.Context) error {
 	}
 	return nil
 }
+
+func versionText() string {
+	info := config.Coolify.Version()
+	if info == nil {
+		return fmt.Sprintf(
+			"<b>🧭 Coolify version</b>\n\nNot detected yet. Using API <code>%s</code> until a probe succeeds.",
+			html.EscapeString(config.Coolify.APIVersion),
+		)
+	}
+	return fmt.Sprintf(
+		"<b>🧭 Coolify version</b>\n\n"+
+			"📦 <b>Coolify:</b> <code>%s</code>\n"+
+			"🔌 <b>API:</b> <code>%s</code>\n"+
+			"🕒 <b>Detected:</b> %s",
+		html.EscapeString(info.CoolifyVersion), html.EscapeString(info.APIVersion),
+		info.ProbedAt.Format("2006-01-02 15:04:05"),
+	)
+}
+
+var versionMarkup = gotgbot.InlineKeyboardMarkup{InlineKeyboard: [][]gotgbot.InlineKeyboardButton{
+	{{Text: "🔄 Probe again", CallbackData: "version_probe"}},
+}}
+
+func versionCommandHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+	if !isAllowed(updateContext(ctx), ctx.EffectiveUser.Id, "list_projects", "") {
+		_, err := msg.Reply(b, "🚫 You are not authorized.", nil)
+		return err
+	}
+
+	_, err := replyHTML(b, msg, versionText(), &versionMarkup)
+	return err
+}
+
+// versionProbeHandler re-runs API version negotiation, e.g. after upgrading Coolify.
+func versionProbeHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	cb := ctx.CallbackQuery
+	if !ensureAllowed(b, ctx) {
+		return nil
+	}
+
+	if _, err := config.Coolify.ProbeVersionWithContext(updateContext(ctx)); err != nil {
+		_, _ = cb.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: "❌ Probe failed: " + errorText(err), ShowAlert: true})
+		return nil
+	}
+	_, _ = cb.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: "✅ Version detected"})
+
+	_, _, err := cb.Message.EditText(b, versionText(), &gotgbot.EditMessageTextOpts{
+		ParseMode:   "HTML",
+		ReplyMarkup: versionMarkup,
+	})
+	return err
+}
@@ -0,0 +1,210 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// document is the on-disk layout of a file store.
+type document struct {
+	Version int                                   `json:"version"`
+	Buckets map[string]map[string]json.RawMessage `json:"buckets"`
+}
+
+// kvStore implements Store in memory, writing the whole document to path after
+// every change when path is set.
+type kvStore struct {
+	mu   sync.RWMutex
+	path string
+	doc  document
+}
+
+// NewMemory returns a Store that is never written to disk.
+func NewMemory() Store {
+	return &kvStore{doc: document{Buckets: make(map[string]map[string]json.RawMessage)}}
+}
+
+// OpenFile loads the store at path, creating it on the first write.
+func OpenFile(path string) (Store, error) {
+	if path == "" {
+		return nil, errors.New("storage: file path is empty")
+	}
+	s := &kvStore{path: path, doc: document{Buckets: make(map[string]map[string]json.RawMessage)}}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &s.doc); err != nil {
+		return nil, err
+	}
+	if s.doc.Buckets == nil {
+		s.doc.Buckets = make(map[string]map[string]json.RawMessage)
+	}
+	return s, nil
+}
+
+func (s *kvStore) Get(bucket, key string, v any) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	raw, ok := s.doc.Buckets[bucket][key]
+	if !ok {
+		return false, nil
+	}
+	return true, json.Unmarshal(raw, v)
+}
+
+func (s *kvStore) Put(bucket, key string, v any) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.update(bucket, func(values map[string]json.RawMessage) {
+		values[key] = raw
+	})
+}
+
+func (s *kvStore) Delete(bucket, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.doc.Buckets[bucket][key]; !ok {
+		return nil
+	}
+	return s.update(bucket, func(values map[string]json.RawMessage) {
+		delete(values, key)
+	})
+}
+
+func (s *kvStore) Append(bucket, key string, v any, limit int) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.update(bucket, func(values map[string]json.RawMessage) {
+		values[key] = raw
+		if limit <= 0 || len(values) <= limit {
+			return
+		}
+		keys := make([]string, 0, len(values))
+		for k := range values {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys[:len(keys)-limit] {
+			delete(values, k)
+		}
+	})
+}
+
+func (s *kvStore) ForEach(bucket string, fn func(key string, value []byte) error) error {
+	s.mu.RLock()
+	// Copy the bucket so fn may call back into the store.
+	values := make(map[string]json.RawMessage, len(s.doc.Buckets[bucket]))
+	keys := make([]string, 0, len(values))
+	for key, value := range s.doc.Buckets[bucket] {
+		values[key] = value
+		keys = append(keys, key)
+	}
+	s.mu.RUnlock()
+
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := fn(key, values[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *kvStore) Version() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.doc.Version
+}
+
+func (s *kvStore) SetVersion(version int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	doc := s.doc
+	doc.Version = version
+	if err := s.flush(doc); err != nil {
+		return err
+	}
+	s.doc = doc
+	return nil
+}
+
+func (s *kvStore) Close() error {
+	return nil
+}
+
+// update applies fn to a copy of bucket and keeps the result only once it has
+// been written, so a failed write leaves the store unchanged. Callers must hold mu.
+func (s *kvStore) update(bucket string, fn func(values map[string]json.RawMessage)) error {
+	values := make(map[string]json.RawMessage, len(s.doc.Buckets[bucket])+1)
+	for key, value := range s.doc.Buckets[bucket] {
+		values[key] = value
+	}
+	fn(values)
+
+	doc := document{Version: s.doc.Version, Buckets: make(map[string]map[string]json.RawMessage, len(s.doc.Buckets)+1)}
+	for name, b := range s.doc.Buckets {
+		doc.Buckets[name] = b
+	}
+	if len(values) == 0 {
+		delete(doc.Buckets, bucket)
+	} else {
+		doc.Buckets[bucket] = values
+	}
+
+	if err := s.flush(doc); err != nil {
+		return err
+	}
+	s.doc = doc
+	return nil
+}
+
+// flush replaces the file with doc via a temp file and rename; callers must hold mu.
+func (s *kvStore) flush(doc document) error {
+	if s.path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), ".tmp-*.json")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
@@ -0,0 +1,35 @@
+package storage
+
+import (
+	"fmt"
+	"log"
+	"sort"
+)
+
+// Migration upgrades the stored data to Version. Migrations run once, in version order.
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(s Store) error
+}
+
+// Migrate applies every migration newer than the store's version, recording
+// the version after each so a failed run resumes where it stopped.
+func Migrate(s Store, migrations []Migration) error {
+	sorted := append([]Migration(nil), migrations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+
+	for _, m := range sorted {
+		if m.Version <= s.Version() {
+			continue
+		}
+		if err := m.Up(s); err != nil {
+			return fmt.Errorf("migration %d (%s): %w", m.Version, m.Name, err)
+		}
+		if err := s.SetVersion(m.Version); err != nil {
+			return err
+		}
+		log.Printf("storage: applied migration %d (%s)", m.Version, m.Name)
+	}
+	return nil
+}
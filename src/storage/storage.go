@@ -0,0 +1,37 @@
+// Package storage persists bot state that must survive restarts, such as role
+// grants, subscriptions and schedules.
+package storage
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Store is a bucketed key/value store. Values are JSON encoded.
+type Store interface {
+	// Get decodes the value of key into v. It reports false if the key does not exist.
+	Get(bucket, key string, v any) (bool, error)
+	Put(bucket, key string, v any) error
+	Delete(bucket, key string) error
+	// Append puts v under key and drops the lowest keys of bucket beyond limit,
+	// in a single write. A limit of 0 or less keeps every key.
+	Append(bucket, key string, v any, limit int) error
+	// ForEach calls fn with every key and raw JSON value of bucket, in key order.
+	ForEach(bucket string, fn func(key string, value []byte) error) error
+	// Version is the schema version recorded by the last applied migration.
+	Version() int
+	SetVersion(version int) error
+	Close() error
+}
+
+// Open returns the store for driver: "file" (the default) keeps everything in a
+// single JSON document at path, "memory" keeps state only for the process lifetime.
+func Open(driver, path string) (Store, error) {
+	switch strings.ToLower(strings.TrimSpace(driver)) {
+	case "", "file":
+		return OpenFile(path)
+	case "memory":
+		return NewMemory(), nil
+	}
+	return nil, fmt.Errorf("unknown storage driver %q", driver)
+}
@@ -0,0 +1,264 @@
+package src
+
+import (
+	"fmt"
+	"html"
+	"log"
+	"strings"
+	"time"
+
+	"coolifymanager/src/config"
+	coolifyPkg "coolifymanager/src/coolity"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+)
+
+const (
+	severityOK = iota
+	severityUnhealthy
+	severityDown
+)
+
+type watchedResource struct {
+	kind     string // "app" or "db"
+	uuid     string
+	name     string
+	status   string
+	severity int
+}
+
+type statusWatcher struct {
+	bot      *gotgbot.Bot
+	interval time.Duration
+	// last holds the most recently seen state, keyed by kind and UUID.
+	last map[string]watchedResource
+	// backups holds the latest seen "<execution uuid>:<status>" per database backup
+	// schedule, so an execution first seen running is reported once it fails.
+	backups map[string]string
+}
+
+// StartWatcher polls applications and databases in the background and alerts
+// subscribers (or LOG_ID) whenever a resource changes health. Failed scheduled
+// database backups are reported to LOG_ID.
+func StartWatcher(b *gotgbot.Bot) {
+	interval := config.WatchInterval()
+	if interval <= 0 {
+		log.Println("status watcher disabled (WATCH_INTERVAL_SECONDS=0)")
+		return
+	}
+
+	w := &statusWatcher{
+		bot:      b,
+		interval: interval,
+		last:     make(map[string]watchedResource),
+		backups:  make(map[string]string),
+	}
+	go w.run()
+}
+
+func (w *statusWatcher) run() {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	first := true
+	for {
+		current, err := w.snapshot()
+		if err != nil {
+			log.Printf("status watcher: %v", err)
+		} else {
+			w.diff(current, first)
+			w.checkBackups(current, first)
+			first = false
+		}
+		<-ticker.C
+	}
+}
+
+func (w *statusWatcher) snapshot() (map[string]watchedResource, error) {
+	current := make(map[string]watchedResource)
+
+	apps, err := config.Coolify.ListApplications(0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("list applications: %w", err)
+	}
+	for _, app := range apps.Results() {
+		r := watchedResource{kind: "app", uuid: app.UUID, name: app.Name, status: app.Status, severity: statusSeverity(app.Status)}
+		current["app:"+app.UUID] = r
+	}
+
+	dbs, err := config.Coolify.ListDatabases(0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("list databases: %w", err)
+	}
+	for _, db := range dbs.Results() {
+		r := watchedResource{kind: "db", uuid: db.UUID, name: db.Name, status: db.Status, severity: statusSeverity(db.Status)}
+		current["db:"+db.UUID] = r
+	}
+	return current, nil
+}
+
+// diff alerts on severity transitions. The first snapshot only records a baseline,
+// and repeated polls in the same severity never re-alert.
+func (w *statusWatcher) diff(current map[string]watchedResource, baseline bool) {
+	for key, now := range current {
+		prev, known := w.last[key]
+		w.last[key] = now
+		if baseline || !known || prev.severity == now.severity {
+			continue
+		}
+		w.alert(prev, now)
+	}
+	for key := range w.last {
+		if _, ok := current[key]; !ok {
+			delete(w.last, key)
+		}
+	}
+}
+
+func (w *statusWatcher) alert(prev, now watchedResource) {
+	icon, verb := "🟢", "recovered"
+	switch now.severity {
+	case severityUnhealthy:
+		icon, verb = "🟠", "is unhealthy"
+	case severityDown:
+		icon, verb = "🔴", "is down"
+	}
+
+	kind := "Application"
+	if now.kind == "db" {
+		kind = "Database"
+	}
+	text := fmt.Sprintf(
+		"%s <b>%s %s</b> %s\n<code>%s</code> → <code>%s</code>\nUUID: <code>%s</code>\n<b>Time:</b> %s",
+		icon, kind, html.EscapeString(now.name), verb,
+		html.EscapeString(orUnknown(prev.status)), html.EscapeString(orUnknown(now.status)),
+		now.uuid, time.Now().Format(time.RFC3339),
+	)
+
+	opts := &gotgbot.SendMessageOpts{ParseMode: "HTML"}
+	if now.kind == "app" {
+		opts.ReplyMarkup = gotgbot.InlineKeyboardMarkup{InlineKeyboard: [][]gotgbot.InlineKeyboardButton{
+			{{Text: "📦 Open", CallbackData: "project_menu:" + now.uuid}, {Text: "📜 Logs", CallbackData: "logs:" + now.uuid}},
+		}}
+	}
+
+	chats := config.Subscribers(now.uuid)
+	if len(chats) == 0 && config.LogChat() != 0 {
+		chats = []int64{config.LogChat()}
+	}
+	for _, chatID := range chats {
+		if _, err := w.bot.SendMessage(chatID, text, opts); err != nil {
+			log.Printf("status watcher: failed to alert %d: %v", chatID, err)
+		}
+	}
+}
+
+// checkBackups alerts LOG_ID about backup executions that failed since the last poll.
+func (w *statusWatcher) checkBackups(current map[string]watchedResource, baseline bool) {
+	if config.LogChat() == 0 {
+		return
+	}
+	for _, r := range current {
+		if r.kind != "db" {
+			continue
+		}
+		schedules, err := config.Coolify.ListDatabaseBackups(r.uuid)
+		if err != nil {
+			log.Printf("status watcher: backups of %s: %v", r.uuid, err)
+			continue
+		}
+		for _, s := range schedules {
+			latest := s.LatestExecution()
+			if latest == nil {
+				continue
+			}
+			key := r.uuid + ":" + s.UUID
+			state := latest.UUID + ":" + strings.ToLower(latest.Status)
+			seen := w.backups[key]
+			w.backups[key] = state
+			if baseline || seen == state || !strings.EqualFold(latest.Status, "failed") {
+				continue
+			}
+			w.alertBackup(r, s, *latest)
+		}
+	}
+}
+
+func (w *statusWatcher) alertBackup(db watchedResource, s coolifyPkg.ScheduledBackup, e coolifyPkg.BackupExecution) {
+	text := fmt.Sprintf(
+		"❌ <b>Backup of %s failed</b>\nSchedule: <code>%s</code>\n%s\nUUID: <code>%s</code>",
+		html.EscapeString(db.name), html.EscapeString(s.Frequency), describeExecution(e), db.uuid,
+	)
+	if e.Message != "" {
+		text += "\n<pre>" + html.EscapeString(truncateText(e.Message, 500)) + "</pre>"
+	}
+
+	_, err := w.bot.SendMessage(config.LogChat(), text, &gotgbot.SendMessageOpts{
+		ParseMode: "HTML",
+		ReplyMarkup: gotgbot.InlineKeyboardMarkup{InlineKeyboard: [][]gotgbot.InlineKeyboardButton{
+			{{Text: "💾 Backups", CallbackData: "db_backups:" + db.uuid}},
+		}},
+	})
+	if err != nil {
+		log.Printf("status watcher: failed to report backup of %s: %v", db.uuid, err)
+	}
+}
+
+// statusSeverity classifies Coolify's "state:health" status strings.
+func statusSeverity(status string) int {
+	state, health, _ := strings.Cut(strings.ToLower(status), ":")
+	switch {
+	case state == "running" && health == "unhealthy", state == "degraded", state == "restarting":
+		return severityUnhealthy
+	case state == "running", state == "starting", state == "":
+		return severityOK
+	default:
+		return severityDown
+	}
+}
+
+func orUnknown(s string) string {
+	if s == "" {
+		return "unknown"
+	}
+	return s
+}
+
+func subscribeCommandHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	app, _ := resolveAppCommand(b, ctx, "status", "/subscribe <name|uuid>")
+	if app == nil {
+		return nil
+	}
+
+	added, err := config.Subscribe(app.UUID, ctx.EffectiveChat.Id)
+	if err != nil {
+		_, err = ctx.EffectiveMessage.Reply(b, "❌ Failed to save subscription: "+errorText(err), nil)
+		return err
+	}
+	text := fmt.Sprintf("🔔 This chat will be alerted when <b>%s</b> changes health.", html.EscapeString(app.Name))
+	if !added {
+		text = fmt.Sprintf("ℹ️ This chat is already subscribed to <b>%s</b>.", html.EscapeString(app.Name))
+	}
+	_, err = replyHTML(b, ctx.EffectiveMessage, text, nil)
+	return err
+}
+
+func unsubscribeCommandHandler(b *gotgbot.Bot, ctx *ext.Context) error {
+	app, _ := resolveAppCommand(b, ctx, "status", "/unsubscribe <name|uuid>")
+	if app == nil {
+		return nil
+	}
+
+	removed, err := config.Unsubscribe(app.UUID, ctx.EffectiveChat.Id)
+	if err != nil {
+		_, err = ctx.EffectiveMessage.Reply(b, "❌ Failed to save subscription: "+errorText(err), nil)
+		return err
+	}
+	text := fmt.Sprintf("🔕 Alerts for <b>%s</b> turned off in this chat.", html.EscapeString(app.Name))
+	if !removed {
+		text = fmt.Sprintf("ℹ️ This chat is not subscribed to <b>%s</b>.", html.EscapeString(app.Name))
+	}
+	_, err = replyHTML(b, ctx.EffectiveMessage, text, nil)
+	return err
+}
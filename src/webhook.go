@@ -0,0 +1,169 @@
+package src
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"coolifymanager/src/config"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+)
+
+const maxWebhookBody = 1 << 20
+
+// coolifyNotification is the JSON body Coolify's webhook notification channel posts.
+// Fields are populated depending on the event type.
+type coolifyNotification struct {
+	Success         bool   `json:"success"`
+	Message         string `json:"message"`
+	Event           string `json:"event"`
+	ApplicationName string `json:"application_name"`
+	ApplicationUUID string `json:"application_uuid"`
+	DeploymentUUID  string `json:"deployment_uuid"`
+	DeploymentURL   string `json:"deployment_url"`
+	Project         string `json:"project"`
+	Environment     string `json:"environment"`
+	FQDN            string `json:"fqdn"`
+	ContainerName   string `json:"container_name"`
+	ServerName      string `json:"server_name"`
+	DatabaseName    string `json:"database_name"`
+	DatabaseUUID    string `json:"database_uuid"`
+	Frequency       string `json:"frequency"`
+	ErrorOutput     string `json:"error_output"`
+	URL             string `json:"url"`
+}
+
+// CoolifyWebhookHandler accepts Coolify webhook notifications and forwards them to
+// config.NotifyChats. Requests must carry COOLIFY_WEBHOOK_SECRET either as a
+// bearer token or as the "token" query parameter.
+func CoolifyWebhookHandler(b *gotgbot.Bot) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if !webhookAuthorized(r) {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBody))
+		if err != nil {
+			http.Error(w, "failed to read body", http.StatusBadRequest)
+			return
+		}
+
+		var n coolifyNotification
+		if err := json.Unmarshal(body, &n); err != nil {
+			http.Error(w, "invalid JSON", http.StatusBadRequest)
+			return
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+		go fanOutNotification(b, n)
+	})
+}
+
+func webhookAuthorized(r *http.Request) bool {
+	secret := config.CoolifyWebhookSecret
+	if secret == "" {
+		return false
+	}
+
+	token := r.URL.Query().Get("token")
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		token = strings.TrimPrefix(auth, "Bearer ")
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(secret)) == 1
+}
+
+func fanOutNotification(b *gotgbot.Bot, n coolifyNotification) {
+	chats := config.NotifyChats()
+	if len(chats) == 0 {
+		log.Printf("coolify webhook: no chats configured for event %q", n.Event)
+		return
+	}
+
+	text, markup := renderNotification(n)
+	opts := &gotgbot.SendMessageOpts{
+		ParseMode:          "HTML",
+		ReplyMarkup:        markup,
+		LinkPreviewOptions: &gotgbot.LinkPreviewOptions{IsDisabled: true},
+	}
+	for _, chatID := range chats {
+		if _, err := b.SendMessage(chatID, text, opts); err != nil {
+			log.Printf("coolify webhook: failed to notify %d: %v", chatID, err)
+		}
+	}
+}
+
+func renderNotification(n coolifyNotification) (string, gotgbot.InlineKeyboardMarkup) {
+	icon, title := "🔔", "Coolify notification"
+	switch n.Event {
+	case "deployment_success":
+		icon, title = "✅", "Deployment succeeded"
+	case "deployment_failed":
+		icon, title = "❌", "Deployment failed"
+	case "container_stopped", "status_changed":
+		icon, title = "🛑", "Container stopped"
+	case "container_restarted":
+		icon, title = "🔄", "Container restarted"
+	case "backup_success":
+		icon, title = "💾", "Backup succeeded"
+	case "backup_failed":
+		icon, title = "🚨", "Backup failed"
+	case "test":
+		icon, title = "🧪", "Test notification"
+	default:
+		if n.Event != "" {
+			title = "Coolify: " + strings.ReplaceAll(n.Event, "_", " ")
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s <b>%s</b>\n", icon, html.EscapeString(title)))
+	writeField := func(label, value string) {
+		if value != "" {
+			sb.WriteString(fmt.Sprintf("<b>%s:</b> %s\n", label, html.EscapeString(value)))
+		}
+	}
+	writeField("App", n.ApplicationName)
+	writeField("Database", n.DatabaseName)
+	writeField("Container", n.ContainerName)
+	writeField("Server", n.ServerName)
+	writeField("Project", n.Project)
+	writeField("Environment", n.Environment)
+	writeField("URL", n.FQDN)
+	writeField("Frequency", n.Frequency)
+	if n.Message != "" {
+		sb.WriteString("\n" + html.EscapeString(n.Message) + "\n")
+	}
+	if n.ErrorOutput != "" {
+		sb.WriteString("\n<pre>" + html.EscapeString(truncateText(n.ErrorOutput, 1500)) + "</pre>\n")
+	}
+	sb.WriteString(fmt.Sprintf("\n<b>Time:</b> %s", time.Now().Format(time.RFC3339)))
+
+	var rows [][]gotgbot.InlineKeyboardButton
+	if n.ApplicationUUID != "" {
+		rows = append(rows, []gotgbot.InlineKeyboardButton{
+			{Text: "📜 Logs", CallbackData: "logs:" + n.ApplicationUUID},
+			{Text: "🚀 Redeploy", CallbackData: "deploy:" + n.ApplicationUUID},
+		})
+	}
+	link := n.DeploymentURL
+	if link == "" {
+		link = n.URL
+	}
+	if strings.HasPrefix(link, "http") {
+		rows = append(rows, []gotgbot.InlineKeyboardButton{{Text: "🔗 Open in Coolify", Url: link}})
+	}
+	return sb.String(), gotgbot.InlineKeyboardMarkup{InlineKeyboard: rows}
+}